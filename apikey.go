@@ -0,0 +1,39 @@
+package goverseerr
+
+import (
+	"context"
+	"fmt"
+)
+
+// SetAPIKey changes the API key sent with requests. It is safe to call while
+// other requests are in flight.
+func (o *Overseerr) SetAPIKey(apikey string) {
+	o.apiKey.Store(&apikey)
+}
+
+// RotateAPIKey regenerates the instance's API key and switches the client to
+// it. The new key is verified before persist is called with it, persist
+// should save the key wherever it is kept, e.g. a secret store. The old key
+// stops working as soon as it is regenerated, so if verification or persist
+// fails the new key is still returned alongside the error.
+func (o *Overseerr) RotateAPIKey(ctx context.Context, persist func(apikey string) error) (string, error) {
+	o.rotateMu.Lock()
+	defer o.rotateMu.Unlock()
+	settings, err := o.RegenerateMainSettingsContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to regenerate api key: %w", err)
+	}
+	if settings.APIKey == "" {
+		return "", fmt.Errorf("no api key contained in response")
+	}
+	o.SetAPIKey(settings.APIKey)
+	if _, err := o.GetLoggedInUserContext(ctx); err != nil {
+		return settings.APIKey, fmt.Errorf("failed to verify new api key: %w", err)
+	}
+	if persist != nil {
+		if err := persist(settings.APIKey); err != nil {
+			return settings.APIKey, fmt.Errorf("failed to persist new api key: %w", err)
+		}
+	}
+	return settings.APIKey, nil
+}
@@ -0,0 +1,29 @@
+package goverseerr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRotateAPIKeyCancelled(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	persisted := false
+	_, err := o.RotateAPIKey(ctx, func(apikey string) error {
+		persisted = true
+		return nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second || persisted {
+		t.Errorf("rotation returned after %s, persisted = %t, want it abandoned at the deadline", elapsed, persisted)
+	}
+}
@@ -0,0 +1,36 @@
+package goverseerr
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	defaultPollInitial time.Duration = 500 * time.Millisecond
+	defaultPollMax     time.Duration = 10 * time.Second
+)
+
+// backoff waits between polls, doubling the delay each time up to max.
+type backoff struct {
+	next time.Duration
+	max  time.Duration
+}
+
+func newBackoff() *backoff {
+	return &backoff{next: defaultPollInitial, max: defaultPollMax}
+}
+
+func (b *backoff) wait(ctx context.Context) error {
+	timer := time.NewTimer(b.next)
+	defer timer.Stop()
+	b.next *= 2
+	if b.next > b.max {
+		b.next = b.max
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,400 @@
+package goverseerr
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// SnapshotVersion is the snapshot format version written by Backup.
+const SnapshotVersion int = 1
+
+const backupUsersPageSize int = 100
+
+type SnapshotSection string
+
+const (
+	SnapshotSectionMain          SnapshotSection = "main"
+	SnapshotSectionPlex          SnapshotSection = "plex"
+	SnapshotSectionRadarr        SnapshotSection = "radarr"
+	SnapshotSectionSonarr        SnapshotSection = "sonarr"
+	SnapshotSectionNotifications SnapshotSection = "notifications"
+	SnapshotSectionJobs          SnapshotSection = "jobs"
+	SnapshotSectionUsers         SnapshotSection = "users"
+)
+
+// Snapshot holds everything configurable through the API. Public settings
+// and the server version are recorded for reference only and are never
+// restored.
+type Snapshot struct {
+	Version       int                                  `json:"version"`
+	Created       time.Time                            `json:"createdAt"`
+	ServerVersion string                               `json:"serverVersion"`
+	Main          *MainSettings                        `json:"main"`
+	Public        *PublicSettings                      `json:"public"`
+	Plex          *PlexSettings                        `json:"plex"`
+	Radarr        []*RadarrSettings                    `json:"radarr"`
+	Sonarr        []*SonarrSettings                    `json:"sonarr"`
+	Notifications map[NotificationAgent]map[string]any `json:"notifications"`
+	Jobs          []*Job                               `json:"jobs"`
+	Users         []*UserSnapshot                      `json:"users"`
+}
+
+// UserSnapshot is a user's permissions and quotas. Users are matched by
+// email when restoring.
+type UserSnapshot struct {
+	Email       string               `json:"email"`
+	UserType    UserType             `json:"userType"`
+	Permissions int                  `json:"permissions"`
+	Settings    GenerealUserSettings `json:"settings"`
+}
+
+type RestoreOptions struct {
+	// Sections limits the restore to the given sections, all sections are
+	// restored if empty.
+	Sections []SnapshotSection
+	// DryRun plans the restore without making any changes.
+	DryRun bool
+	// Prune deletes Radarr and Sonarr servers that are not in the snapshot,
+	// for the sections being restored.
+	Prune bool
+}
+
+// RestoreReport describes the changes a restore made, or would make for a
+// dry run. Skipped lists snapshot entries that could not be restored.
+type RestoreReport struct {
+	Plan    *Plan
+	Applied int
+	Skipped []string
+}
+
+// Backup collects the instance's configuration into a snapshot.
+func (o *Overseerr) Backup(ctx context.Context) (*Snapshot, error) {
+	snapshot := Snapshot{
+		Version:       SnapshotVersion,
+		Created:       time.Now(),
+		Notifications: make(map[NotificationAgent]map[string]any, len(NotificationAgents)),
+	}
+	steps := []func() error{
+		func() (err error) {
+			about, err := o.GetAbout()
+			if err == nil {
+				snapshot.ServerVersion = about.Version
+			}
+			return err
+		},
+		func() (err error) { snapshot.Main, err = o.GetMainSettings(); return },
+		func() (err error) { snapshot.Public, err = o.GetPublicSettings(); return },
+		func() (err error) { snapshot.Plex, err = o.GetPlexSettings(); return },
+		func() (err error) { snapshot.Radarr, err = o.GetRadarrSettings(); return },
+		func() (err error) { snapshot.Sonarr, err = o.GetSonarrSettings(); return },
+		func() (err error) { snapshot.Jobs, err = o.GetJobs(); return },
+		func() error {
+			for _, agent := range NotificationAgents {
+				settings, err := GetNotificationSettings[map[string]any](o, agent)
+				if err != nil {
+					return fmt.Errorf("%s notifications: %w", agent, err)
+				}
+				snapshot.Notifications[agent], err = toMap(settings)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		func() (err error) { snapshot.Users, err = o.backupUsers(ctx); return },
+	}
+	for _, step := range steps {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+	return &snapshot, nil
+}
+
+func (o *Overseerr) backupUsers(ctx context.Context) ([]*UserSnapshot, error) {
+	users, err := o.allUsers()
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]*UserSnapshot, 0, len(users))
+	for _, user := range users {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		settings, err := o.GetUserGeneralSettings(user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("user %d settings: %w", user.ID, err)
+		}
+		snapshots = append(snapshots, &UserSnapshot{
+			Email:       user.Email,
+			UserType:    user.UserType,
+			Permissions: user.Permissions,
+			Settings:    *settings,
+		})
+	}
+	return snapshots, nil
+}
+
+func (o *Overseerr) allUsers() ([]*User, error) {
+	var users []*User
+	for page := 0; ; page++ {
+		batch, pageInfo, err := o.GetAllUsers(backupUsersPageSize, page)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, batch...)
+		if len(batch) == 0 || page+1 >= pageInfo.Pages {
+			break
+		}
+	}
+	return users, nil
+}
+
+// WriteSnapshot writes a snapshot to w as gzipped JSON.
+func WriteSnapshot(w io.Writer, snapshot *Snapshot) error {
+	gw := gzip.NewWriter(w)
+	enc := json.NewEncoder(gw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(snapshot); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// ReadSnapshot reads a snapshot written by WriteSnapshot. Snapshots from a
+// newer version of this package are rejected.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+	var snapshot Snapshot
+	if err := json.NewDecoder(gr).Decode(&snapshot); err != nil {
+		return nil, err
+	}
+	if snapshot.Version < 1 || snapshot.Version > SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version: %d", snapshot.Version)
+	}
+	return &snapshot, nil
+}
+
+// Restore replays a snapshot onto the instance. Settings are only changed
+// where they differ from the snapshot. Plex libraries are matched by name
+// so the instance's library list must be up to date, and missing Plex users
+// must be imported from Plex before their permissions can be restored.
+func (o *Overseerr) Restore(ctx context.Context, snapshot *Snapshot, opts RestoreOptions) (*RestoreReport, error) {
+	if snapshot.Version < 1 || snapshot.Version > SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version: %d", snapshot.Version)
+	}
+	cfg, skipped, err := snapshot.config(opts)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := o.Plan(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	report := RestoreReport{Plan: plan, Skipped: skipped}
+	if opts.includes(SnapshotSectionUsers) {
+		skipped, err := o.planUsers(ctx, snapshot.Users, plan)
+		if err != nil {
+			return nil, err
+		}
+		report.Skipped = append(report.Skipped, skipped...)
+	}
+	if opts.DryRun {
+		return &report, nil
+	}
+	report.Applied, err = o.Apply(ctx, plan)
+	return &report, err
+}
+
+func (opts RestoreOptions) includes(section SnapshotSection) bool {
+	if len(opts.Sections) == 0 {
+		return true
+	}
+	for _, s := range opts.Sections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+// config converts the selected sections of the snapshot into a Config,
+// returning the entries that cannot be restored.
+func (s *Snapshot) config(opts RestoreOptions) (*Config, []string, error) {
+	var cfg Config
+	if opts.includes(SnapshotSectionMain) && s.Main != nil {
+		main, err := toMap(s.Main)
+		if err != nil {
+			return nil, nil, err
+		}
+		// the API key is managed by RegenerateMainSettings
+		delete(main, "apiKey")
+		cfg.Main = main
+	}
+	if opts.includes(SnapshotSectionPlex) && s.Plex != nil {
+		settings, err := toMap(s.Plex)
+		if err != nil {
+			return nil, nil, err
+		}
+		delete(settings, "libraries")
+		cfg.Plex = &PlexConfig{Settings: settings}
+		for _, library := range s.Plex.Libraries {
+			if library.Enabled {
+				cfg.Plex.Libraries = append(cfg.Plex.Libraries, library.Name)
+			}
+		}
+	}
+	if opts.includes(SnapshotSectionRadarr) {
+		cfg.Radarr = &ServersConfig{Prune: opts.Prune}
+		for _, server := range s.Radarr {
+			settings, err := toMap(server)
+			if err != nil {
+				return nil, nil, err
+			}
+			delete(settings, "id")
+			cfg.Radarr.Servers = append(cfg.Radarr.Servers, settings)
+		}
+	}
+	if opts.includes(SnapshotSectionSonarr) {
+		cfg.Sonarr = &ServersConfig{Prune: opts.Prune}
+		for _, server := range s.Sonarr {
+			settings, err := toMap(server)
+			if err != nil {
+				return nil, nil, err
+			}
+			delete(settings, "id")
+			cfg.Sonarr.Servers = append(cfg.Sonarr.Servers, settings)
+		}
+	}
+	if opts.includes(SnapshotSectionNotifications) {
+		cfg.Notifications = s.Notifications
+	}
+	var skipped []string
+	if opts.includes(SnapshotSectionJobs) && len(s.Jobs) > 0 {
+		cfg.Jobs = make(map[JobID]string, len(s.Jobs))
+		for _, job := range s.Jobs {
+			// older servers may not report a schedule for every job
+			if _, err := ParseCronSchedule(job.CronSchedule); err != nil {
+				skipped = append(skipped, fmt.Sprintf("job %s: unusable schedule %q", job.ID, job.CronSchedule))
+				continue
+			}
+			cfg.Jobs[job.ID] = job.CronSchedule
+		}
+	}
+	return &cfg, skipped, nil
+}
+
+// planUsers adds changes restoring user permissions and quotas to the plan,
+// returning the users that cannot be restored.
+func (o *Overseerr) planUsers(ctx context.Context, snapshots []*UserSnapshot, plan *Plan) ([]string, error) {
+	users, err := o.allUsers()
+	if err != nil {
+		return nil, err
+	}
+	byEmail := make(map[string]*User, len(users))
+	for _, user := range users {
+		byEmail[user.Email] = user
+	}
+	var skipped []string
+	for _, snapshot := range snapshots {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		snapshot := snapshot
+		// only the modelled settings are restored, the rest are kept as is
+		desired := snapshot.Settings
+		desired.Extra = nil
+		desiredSettings, err := toMap(desired)
+		if err != nil {
+			return nil, err
+		}
+		user, ok := byEmail[snapshot.Email]
+		if !ok {
+			if snapshot.UserType != UserTypeLocal {
+				skipped = append(skipped, fmt.Sprintf("user %s: Plex user must be imported from Plex", snapshot.Email))
+				continue
+			}
+			plan.Changes = append(plan.Changes, Change{
+				Section: "users",
+				Name:    snapshot.Email,
+				Action:  ChangeCreate,
+				Diffs:   []FieldDiff{{Path: "permissions", To: snapshot.Permissions}},
+				apply: func(o *Overseerr) error {
+					created, err := o.CreateNewUser(User{Email: snapshot.Email, Permissions: snapshot.Permissions})
+					if err != nil {
+						return err
+					}
+					return o.restoreUserSettings(created.ID, desiredSettings)
+				},
+			})
+			continue
+		}
+		settings, err := o.GetUserGeneralSettings(user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("user %d settings: %w", user.ID, err)
+		}
+		var diffs []FieldDiff
+		if user.Permissions != snapshot.Permissions {
+			diffs = append(diffs, FieldDiff{Path: "permissions", From: user.Permissions, To: snapshot.Permissions})
+		}
+		liveSettings, err := toMap(settings)
+		if err != nil {
+			return nil, err
+		}
+		settingsDiffs := subsetDiff("settings", liveSettings, desiredSettings)
+		diffs = append(diffs, settingsDiffs...)
+		if len(diffs) == 0 {
+			continue
+		}
+		updatePermissions := user.Permissions != snapshot.Permissions
+		updateSettings := len(settingsDiffs) > 0
+		plan.Changes = append(plan.Changes, Change{
+			Section: "users",
+			Name:    snapshot.Email,
+			Action:  ChangeUpdate,
+			Diffs:   diffs,
+			apply: func(o *Overseerr) error {
+				if updatePermissions {
+					current, err := o.GetUser(user.ID)
+					if err != nil {
+						return err
+					}
+					current.Permissions = snapshot.Permissions
+					if _, err := o.UpdateUser(user.ID, *current); err != nil {
+						return err
+					}
+				}
+				if updateSettings {
+					return o.restoreUserSettings(user.ID, desiredSettings)
+				}
+				return nil
+			},
+		})
+	}
+	return skipped, nil
+}
+
+// restoreUserSettings overlays the desired settings onto the user's current
+// settings, so settings the snapshot does not hold are left unchanged.
+func (o *Overseerr) restoreUserSettings(userID int, desired map[string]any) error {
+	current, err := o.GetUserGeneralSettings(userID)
+	if err != nil {
+		return err
+	}
+	var updated GenerealUserSettings
+	if err := overlayInto(current, desired, &updated); err != nil {
+		return err
+	}
+	return o.SetUserGeneralSettings(userID, updated)
+}
@@ -0,0 +1,148 @@
+package goverseerr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSnapshotConfigPrunesSelectedSections(t *testing.T) {
+	snapshot := &Snapshot{
+		Version: SnapshotVersion,
+		Radarr:  []*RadarrSettings{{ID: 3, Name: "radarr", APIKey: "key"}},
+		Sonarr:  []*SonarrSettings{{ID: 4, Name: "sonarr"}},
+	}
+	cfg, _, err := snapshot.config(RestoreOptions{Sections: []SnapshotSection{SnapshotSectionRadarr}, Prune: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Sonarr != nil {
+		t.Fatalf("sonarr = %+v, want nil when the section is not restored", cfg.Sonarr)
+	}
+	if cfg.Radarr == nil || !cfg.Radarr.Prune || len(cfg.Radarr.Servers) != 1 {
+		t.Fatalf("radarr = %+v, want one server with prune", cfg.Radarr)
+	}
+	if _, ok := cfg.Radarr.Servers[0]["id"]; ok {
+		t.Fatalf("radarr server config includes its id")
+	}
+
+	cfg, _, err = snapshot.config(RestoreOptions{Prune: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Radarr == nil || !cfg.Radarr.Prune || cfg.Sonarr == nil || !cfg.Sonarr.Prune {
+		t.Fatalf("radarr = %+v, sonarr = %+v, want both pruned when restoring all sections", cfg.Radarr, cfg.Sonarr)
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	snapshot := &Snapshot{
+		Version: SnapshotVersion,
+		Users:   []*UserSnapshot{{Email: "user@example.com", Permissions: 32}},
+	}
+	var buf bytes.Buffer
+	if err := WriteSnapshot(&buf, snapshot); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadSnapshot(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read.Users) != 1 || read.Users[0].Email != "user@example.com" || read.Users[0].Permissions != 32 {
+		t.Fatalf("users = %+v, want the written user", read.Users)
+	}
+
+	buf.Reset()
+	snapshot.Version = SnapshotVersion + 1
+	if err := WriteSnapshot(&buf, snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadSnapshot(&buf); err == nil {
+		t.Fatal("ReadSnapshot accepted a snapshot from a newer version")
+	}
+}
+
+func TestRestoreKeepsUnsnapshottedState(t *testing.T) {
+	const template = `{"subject": "{{subject}}"}`
+	posted := make(map[string]map[string]any)
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			posted[r.URL.Path] = body
+		}
+		switch r.URL.Path {
+		case "/api/v1/settings/notifications/webhook":
+			json.NewEncoder(w).Encode(map[string]any{
+				"enabled": false,
+				"types":   0,
+				"options": map[string]any{"webhookUrl": "http://example.com", "jsonPayload": template},
+			})
+		case "/api/v1/settings/jobs":
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"id": "plex-recently-added-scan", "cronSchedule": "0 */5 * * * *"},
+				{"id": "download-sync", "cronSchedule": ""},
+			})
+		case "/api/v1/settings/jobs/plex-recently-added-scan/schedule":
+			json.NewEncoder(w).Encode(map[string]any{"id": "plex-recently-added-scan"})
+		case "/api/v1/user":
+			json.NewEncoder(w).Encode(map[string]any{
+				"pageInfo": map[string]any{"pages": 1},
+				"results":  []map[string]any{{"id": 7, "email": "user@example.com", "userType": 2}},
+			})
+		case "/api/v1/user/7/settings/main":
+			json.NewEncoder(w).Encode(map[string]any{
+				"username":            "user",
+				"locale":              "en",
+				"region":              "GB",
+				"watchlistSyncMovies": true,
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	snapshot := &Snapshot{
+		Version: SnapshotVersion,
+		Notifications: map[NotificationAgent]map[string]any{
+			NotificationAgentWebhook: {"enabled": true, "options": map[string]any{"webhookUrl": "http://example.com", "jsonPayload": template}},
+		},
+		Jobs: []*Job{
+			{ID: "plex-recently-added-scan", CronSchedule: "0 */10 * * * *"},
+			{ID: "download-sync", CronSchedule: ""},
+		},
+		Users: []*UserSnapshot{{
+			Email:    "user@example.com",
+			UserType: UserTypeLocal,
+			Settings: GenerealUserSettings{Username: "user", Locale: "fr", MovieQuotaLimit: 5},
+		}},
+	}
+	report, err := o.Restore(context.Background(), snapshot, RestoreOptions{
+		Sections: []SnapshotSection{SnapshotSectionNotifications, SnapshotSectionJobs, SnapshotSectionUsers},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Skipped) != 1 || !strings.Contains(report.Skipped[0], "download-sync") {
+		t.Errorf("skipped = %v, want the job without a schedule", report.Skipped)
+	}
+	if got := posted["/api/v1/settings/jobs/plex-recently-added-scan/schedule"]["schedule"]; got != "0 */10 * * * *" {
+		t.Errorf("job schedule = %v, want the snapshot schedule", got)
+	}
+
+	options, _ := posted["/api/v1/settings/notifications/webhook"]["options"].(map[string]any)
+	var payload string
+	if err := json.Unmarshal([]byte(options["jsonPayload"].(string)), &payload); err != nil || payload != template {
+		t.Errorf("jsonPayload = %q, want the template JSON encoded", options["jsonPayload"])
+	}
+
+	settings := posted["/api/v1/user/7/settings/main"]
+	want := map[string]any{"username": "user", "locale": "fr", "region": "GB", "watchlistSyncMovies": true, "movieQuotaLimit": float64(5)}
+	for key, value := range want {
+		if settings[key] != value {
+			t.Errorf("user settings %s = %v, want %v", key, settings[key], value)
+		}
+	}
+}
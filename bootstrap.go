@@ -0,0 +1,227 @@
+package goverseerr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type BootstrapOutcome string
+
+const (
+	BootstrapDone    BootstrapOutcome = "done"
+	BootstrapSkipped BootstrapOutcome = "skipped"
+	BootstrapFailed  BootstrapOutcome = "failed"
+)
+
+// BootstrapOptions describes the setup Bootstrap performs.
+type BootstrapOptions struct {
+	// PlexToken is the Plex token of the account that becomes the admin.
+	PlexToken string
+	// PlexServer is the name of the Plex server to use. The first server
+	// owned by the admin account is used if empty.
+	PlexServer string
+	// PlexLibraries lists the names of the libraries to enable. Libraries
+	// are left as they are if empty.
+	PlexLibraries []string
+	Radarr        []RadarrSettings
+	Sonarr        []SonarrSettings
+}
+
+type BootstrapStep struct {
+	Name    string
+	Outcome BootstrapOutcome
+	Detail  string
+	Err     error
+}
+
+type bootstrapTask struct {
+	name string
+	run  func() (BootstrapOutcome, string, error)
+}
+
+type BootstrapReport struct {
+	Steps []BootstrapStep
+}
+
+// Failed returns the step that failed, if any.
+func (r *BootstrapReport) Failed() *BootstrapStep {
+	for idx := range r.Steps {
+		if r.Steps[idx].Outcome == BootstrapFailed {
+			return &r.Steps[idx]
+		}
+	}
+	return nil
+}
+
+// Bootstrap completes the first-run setup of an Overseerr instance: it signs
+// in the admin Plex account, selects the Plex server, enables libraries, adds
+// Radarr and Sonarr servers and marks the instance initialized. Steps that
+// are already complete are skipped, so it is safe to re-run. It stops at the
+// first failing step, the signed in client is returned if sign in succeeded.
+func Bootstrap(ctx context.Context, url string, customHeaders map[string]string, locale string, opts BootstrapOptions) (*Overseerr, *BootstrapReport, error) {
+	var report BootstrapReport
+	o, err := NewPlexAuth(url, customHeaders, locale, opts.PlexToken)
+	if err != nil {
+		report.Steps = append(report.Steps, BootstrapStep{Name: "sign in", Outcome: BootstrapFailed, Err: err})
+		return nil, &report, fmt.Errorf("sign in: %w", err)
+	}
+	report.Steps = append(report.Steps, BootstrapStep{Name: "sign in", Outcome: BootstrapDone})
+	steps := []bootstrapTask{
+		{"plex server", func() (BootstrapOutcome, string, error) { return o.bootstrapPlexServer(opts.PlexServer) }},
+		{"plex libraries", func() (BootstrapOutcome, string, error) { return o.bootstrapPlexLibraries(opts.PlexLibraries) }},
+	}
+	for _, settings := range opts.Radarr {
+		settings := settings
+		steps = append(steps, bootstrapTask{"radarr " + settings.Name, func() (BootstrapOutcome, string, error) { return o.bootstrapRadarr(settings) }})
+	}
+	for _, settings := range opts.Sonarr {
+		settings := settings
+		steps = append(steps, bootstrapTask{"sonarr " + settings.Name, func() (BootstrapOutcome, string, error) { return o.bootstrapSonarr(settings) }})
+	}
+	steps = append(steps, bootstrapTask{"initialize", o.bootstrapInitialize})
+	for _, step := range steps {
+		if err := ctx.Err(); err != nil {
+			return o, &report, err
+		}
+		outcome, detail, err := step.run()
+		if err != nil {
+			outcome = BootstrapFailed
+		}
+		report.Steps = append(report.Steps, BootstrapStep{Name: step.name, Outcome: outcome, Detail: detail, Err: err})
+		if err != nil {
+			return o, &report, fmt.Errorf("%s: %w", step.name, err)
+		}
+	}
+	return o, &report, nil
+}
+
+func (o *Overseerr) bootstrapPlexServer(name string) (BootstrapOutcome, string, error) {
+	current, err := o.GetPlexSettings()
+	if err != nil {
+		return "", "", err
+	}
+	if current.MachineID != "" && (name == "" || current.Name == name) {
+		return BootstrapSkipped, "using " + current.Name, nil
+	}
+	servers, err := o.GetPlexServers()
+	if err != nil {
+		return "", "", err
+	}
+	var server *PlexDevice
+	for _, s := range servers {
+		if (name == "" && s.Owned) || (name != "" && s.Name == name) {
+			server = s
+			break
+		}
+	}
+	if server == nil {
+		if name == "" {
+			return "", "", fmt.Errorf("no owned plex server found")
+		}
+		return "", "", fmt.Errorf("plex server not found: %s", name)
+	}
+	conn := server.PreferredConnection()
+	if conn == nil {
+		return "", "", fmt.Errorf("no reachable connection to plex server: %s", server.Name)
+	}
+	err = o.UpdatePlexSettings(PlexSettings{
+		Name:      server.Name,
+		MachineID: server.ClientIdentifier,
+		IP:        conn.Address,
+		Port:      conn.Port,
+		UseSSL:    conn.Protocol == "https",
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return BootstrapDone, "using " + server.Name, nil
+}
+
+func (o *Overseerr) bootstrapPlexLibraries(names []string) (BootstrapOutcome, string, error) {
+	if len(names) == 0 {
+		return BootstrapSkipped, "no libraries given", nil
+	}
+	libraries, err := o.RefreshPlexLibraries()
+	if err != nil {
+		return "", "", err
+	}
+	missing := make(map[string]bool, len(names))
+	for _, name := range names {
+		missing[name] = true
+	}
+	var ids []string
+	changed := false
+	for _, library := range libraries {
+		enable := containsString(names, library.Name)
+		if enable {
+			ids = append(ids, library.ID)
+			delete(missing, library.Name)
+		}
+		if enable != library.Enabled {
+			changed = true
+		}
+	}
+	if len(missing) > 0 {
+		return "", "", fmt.Errorf("plex libraries not found: %s", strings.Join(sortedSet(missing), ", "))
+	}
+	if !changed {
+		return BootstrapSkipped, "libraries already enabled", nil
+	}
+	if _, err := o.EnablePlexLibraries(ids); err != nil {
+		return "", "", err
+	}
+	return BootstrapDone, "enabled " + strings.Join(names, ", "), nil
+}
+
+func (o *Overseerr) bootstrapRadarr(settings RadarrSettings) (BootstrapOutcome, string, error) {
+	servers, err := o.GetRadarrSettings()
+	if err != nil {
+		return "", "", err
+	}
+	for _, server := range servers {
+		if server.Name == settings.Name {
+			return BootstrapSkipped, "already added", nil
+		}
+	}
+	if err := o.TestRadarr(settings); err != nil {
+		return "", "", fmt.Errorf("connection test failed: %w", err)
+	}
+	if _, err := o.AddRadarr(settings); err != nil {
+		return "", "", err
+	}
+	return BootstrapDone, "", nil
+}
+
+func (o *Overseerr) bootstrapSonarr(settings SonarrSettings) (BootstrapOutcome, string, error) {
+	servers, err := o.GetSonarrSettings()
+	if err != nil {
+		return "", "", err
+	}
+	for _, server := range servers {
+		if server.Name == settings.Name {
+			return BootstrapSkipped, "already added", nil
+		}
+	}
+	if err := o.TestSonarr(settings); err != nil {
+		return "", "", fmt.Errorf("connection test failed: %w", err)
+	}
+	if _, err := o.AddSonarr(settings); err != nil {
+		return "", "", err
+	}
+	return BootstrapDone, "", nil
+}
+
+func (o *Overseerr) bootstrapInitialize() (BootstrapOutcome, string, error) {
+	public, err := o.GetPublicSettings()
+	if err != nil {
+		return "", "", err
+	}
+	if public.Initialized {
+		return BootstrapSkipped, "already initialized", nil
+	}
+	if _, err := o.InitializeSettings(); err != nil {
+		return "", "", err
+	}
+	return BootstrapDone, "", nil
+}
@@ -0,0 +1,184 @@
+package goverseerr
+
+import (
+	"context"
+	"sync"
+)
+
+const defaultBulkWorkers int = 4
+
+// RequestSelector resolves the set of request IDs a bulk action applies to.
+// Selectors that page through the server should stop when ctx is done.
+type RequestSelector interface {
+	SelectRequests(ctx context.Context, o *Overseerr) ([]int, error)
+}
+
+// RequestIDs selects an explicit set of requests by their IDs.
+type RequestIDs []int
+
+// RequestQuery selects every request matching the given filter, optionally
+// narrowed to a single requester and a custom match function.
+type RequestQuery struct {
+	Filter      RequestFilter
+	Sort        RequestSort
+	RequestedBy int
+	PageSize    int
+	Match       func(req *MediaRequest) bool
+}
+
+type BulkAction string
+
+const (
+	BulkActionApprove BulkAction = "approve"
+	BulkActionDecline BulkAction = "decline"
+	BulkActionDelete  BulkAction = "delete"
+	BulkActionRetry   BulkAction = "retry"
+)
+
+type BulkOptions struct {
+	Workers int
+	DryRun  bool
+}
+
+// BulkOutcome is the result of a bulk action against a single request.
+// Request is nil for deletes and dry-runs.
+type BulkOutcome struct {
+	RequestID int
+	Request   *MediaRequest
+	Err       error
+}
+
+type BulkReport struct {
+	Action   BulkAction
+	DryRun   bool
+	Outcomes []BulkOutcome
+}
+
+func (ids RequestIDs) SelectRequests(ctx context.Context, o *Overseerr) ([]int, error) {
+	return ids, nil
+}
+
+func (q RequestQuery) SelectRequests(ctx context.Context, o *Overseerr) ([]int, error) {
+	if q.Filter == "" {
+		q.Filter = RequestFileterAll
+	}
+	if q.Sort == "" {
+		q.Sort = RequestSortAdded
+	}
+	if q.PageSize < 1 {
+		q.PageSize = 50
+	}
+	var ids []int
+	for page := 0; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var requests []*MediaRequest
+		var pageInfo *Page
+		var err error
+		if q.RequestedBy > 0 {
+			requests, pageInfo, err = o.GetRequestsByUserContext(ctx, page, q.PageSize, q.RequestedBy, q.Filter, q.Sort)
+		} else {
+			requests, pageInfo, err = o.GetRequestsContext(ctx, page, q.PageSize, q.Filter, q.Sort)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, req := range requests {
+			if q.Match == nil || q.Match(req) {
+				ids = append(ids, req.ID)
+			}
+		}
+		if len(requests) == 0 || page+1 >= pageInfo.Pages {
+			return ids, nil
+		}
+	}
+}
+
+// BulkApprove approves every selected request using a bounded worker pool.
+func (o *Overseerr) BulkApprove(ctx context.Context, selector RequestSelector, opts BulkOptions) (*BulkReport, error) {
+	return o.bulk(ctx, BulkActionApprove, selector, opts, o.ApproveRequest)
+}
+
+// BulkDecline declines every selected request using a bounded worker pool.
+func (o *Overseerr) BulkDecline(ctx context.Context, selector RequestSelector, opts BulkOptions) (*BulkReport, error) {
+	return o.bulk(ctx, BulkActionDecline, selector, opts, o.DeclineRequest)
+}
+
+// BulkRetry retries every selected request using a bounded worker pool.
+func (o *Overseerr) BulkRetry(ctx context.Context, selector RequestSelector, opts BulkOptions) (*BulkReport, error) {
+	return o.bulk(ctx, BulkActionRetry, selector, opts, o.RetryRequest)
+}
+
+// BulkDelete deletes every selected request using a bounded worker pool.
+func (o *Overseerr) BulkDelete(ctx context.Context, selector RequestSelector, opts BulkOptions) (*BulkReport, error) {
+	return o.bulk(ctx, BulkActionDelete, selector, opts, func(requestID int) (*MediaRequest, error) {
+		return nil, o.DeleteRequest(requestID)
+	})
+}
+
+func (o *Overseerr) bulk(ctx context.Context, action BulkAction, selector RequestSelector, opts BulkOptions, fn func(requestID int) (*MediaRequest, error)) (*BulkReport, error) {
+	ids, err := selector.SelectRequests(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+	report := BulkReport{
+		Action:   action,
+		DryRun:   opts.DryRun,
+		Outcomes: make([]BulkOutcome, len(ids)),
+	}
+	for idx, id := range ids {
+		report.Outcomes[idx].RequestID = id
+	}
+	if opts.DryRun {
+		return &report, nil
+	}
+	workers := opts.Workers
+	if workers < 1 {
+		workers = defaultBulkWorkers
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				outcome := &report.Outcomes[idx]
+				if err := ctx.Err(); err != nil {
+					outcome.Err = err
+					continue
+				}
+				outcome.Request, outcome.Err = fn(outcome.RequestID)
+			}
+		}()
+	}
+	for idx := range ids {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+	return &report, nil
+}
+
+// Succeeded returns the outcomes that completed without error.
+func (r BulkReport) Succeeded() []BulkOutcome {
+	var outcomes []BulkOutcome
+	for _, outcome := range r.Outcomes {
+		if outcome.Err == nil {
+			outcomes = append(outcomes, outcome)
+		}
+	}
+	return outcomes
+}
+
+// Failed returns the outcomes that encountered an error.
+func (r BulkReport) Failed() []BulkOutcome {
+	var outcomes []BulkOutcome
+	for _, outcome := range r.Outcomes {
+		if outcome.Err != nil {
+			outcomes = append(outcomes, outcome)
+		}
+	}
+	return outcomes
+}
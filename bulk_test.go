@@ -0,0 +1,200 @@
+package goverseerr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// pagedRequests serves ids as pages of GET /request results and records the
+// query of every page fetched.
+func pagedRequests(ids []int, queries *[]string) http.HandlerFunc {
+	var mu sync.Mutex
+	return func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*queries = append(*queries, r.URL.RawQuery)
+		mu.Unlock()
+		take, _ := strconv.Atoi(r.URL.Query().Get("take"))
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		var page MediaRequestResponse
+		page.PageInfo.Pages = (len(ids) + take - 1) / take
+		for _, id := range ids[min(skip, len(ids)):min(skip+take, len(ids))] {
+			page.Results = append(page.Results, &MediaRequest{ID: id})
+		}
+		json.NewEncoder(w).Encode(page)
+	}
+}
+
+func TestRequestQuerySelects(t *testing.T) {
+	var queries []string
+	o := newTestClient(t, pagedRequests([]int{1, 2, 3, 4, 5}, &queries))
+	query := RequestQuery{
+		Filter:      RequestFileterPending,
+		RequestedBy: 7,
+		PageSize:    2,
+		Match:       func(req *MediaRequest) bool { return req.ID != 4 },
+	}
+	ids, err := query.SelectRequests(context.Background(), o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 2, 3, 5}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+	if len(queries) != 3 {
+		t.Fatalf("fetched %d pages, want 3", len(queries))
+	}
+	for _, want := range []string{"filter=pending", "requestedBy=7", "skip=4", "take=2"} {
+		if !strings.Contains(queries[2], want) {
+			t.Errorf("last page query %q is missing %s", queries[2], want)
+		}
+	}
+}
+
+func TestRequestQueryCancelled(t *testing.T) {
+	var queries []string
+	o := newTestClient(t, pagedRequests([]int{1, 2, 3, 4, 5}, &queries))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	query := RequestQuery{PageSize: 2, Match: func(req *MediaRequest) bool {
+		cancel()
+		return true
+	}}
+	if _, err := query.SelectRequests(ctx, o); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want %v", err, context.Canceled)
+	}
+	if len(queries) != 1 {
+		t.Errorf("fetched %d pages after cancelling, want 1", len(queries))
+	}
+}
+
+// bulkServer fakes the request action endpoints, failing requests in fail and
+// recording the most actions seen in flight at once.
+type bulkServer struct {
+	fail     map[int]bool
+	calls    atomic.Int32
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (s *bulkServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.calls.Add(1)
+	n := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		peak := s.peak.Load()
+		if n <= peak || s.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	var id int
+	var action string
+	fmt.Sscanf(strings.ReplaceAll(r.URL.Path, "/", " "), " api v1 request %d %s", &id, &action)
+	if s.fail[id] {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if r.Method == http.MethodDelete {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	json.NewEncoder(w).Encode(MediaRequest{ID: id, Status: RequestStatusApproved})
+}
+
+func TestBulkApprove(t *testing.T) {
+	server := &bulkServer{fail: map[int]bool{3: true}}
+	o := newTestClient(t, server.handle)
+	ids := RequestIDs{1, 2, 3, 4, 5, 6}
+	report, err := o.BulkApprove(context.Background(), ids, BulkOptions{Workers: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Action != BulkActionApprove || report.DryRun || len(report.Outcomes) != len(ids) {
+		t.Fatalf("report = %+v, want an approve outcome per request", report)
+	}
+	for idx, outcome := range report.Outcomes {
+		if outcome.RequestID != ids[idx] {
+			t.Errorf("outcome %d is for request %d, want %d", idx, outcome.RequestID, ids[idx])
+		}
+		if outcome.Err == nil && (outcome.Request == nil || outcome.Request.ID != outcome.RequestID) {
+			t.Errorf("outcome for request %d has request %+v", outcome.RequestID, outcome.Request)
+		}
+	}
+	if failed := report.Failed(); len(failed) != 1 || failed[0].RequestID != 3 {
+		t.Errorf("failed = %+v, want request 3", failed)
+	}
+	if n := len(report.Succeeded()); n != 5 {
+		t.Errorf("%d succeeded, want 5", n)
+	}
+	if peak := server.peak.Load(); peak > 2 {
+		t.Errorf("%d actions ran at once, want at most 2 workers", peak)
+	}
+}
+
+func TestBulkDelete(t *testing.T) {
+	server := &bulkServer{}
+	o := newTestClient(t, server.handle)
+	report, err := o.BulkDelete(context.Background(), RequestIDs{1, 2}, BulkOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, outcome := range report.Outcomes {
+		if outcome.Err != nil || outcome.Request != nil {
+			t.Errorf("outcome = %+v, want a successful delete without a request", outcome)
+		}
+	}
+}
+
+func TestBulkDryRun(t *testing.T) {
+	var queries []string
+	var mu sync.Mutex
+	actions := 0
+	list := pagedRequests([]int{1, 2, 3}, &queries)
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			mu.Lock()
+			actions++
+			mu.Unlock()
+		}
+		list(w, r)
+	})
+	report, err := o.BulkDecline(context.Background(), RequestQuery{}, BulkOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.DryRun || len(report.Outcomes) != 3 || len(report.Succeeded()) != 3 {
+		t.Errorf("report = %+v, want 3 untouched outcomes", report)
+	}
+	if actions != 0 {
+		t.Errorf("dry run made %d changes", actions)
+	}
+}
+
+func TestBulkCancelled(t *testing.T) {
+	server := &bulkServer{}
+	o := newTestClient(t, server.handle)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report, err := o.BulkRetry(ctx, RequestIDs{1, 2, 3}, BulkOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, outcome := range report.Outcomes {
+		if !errors.Is(outcome.Err, context.Canceled) {
+			t.Errorf("outcome = %+v, want it cancelled", outcome)
+		}
+	}
+	if calls := server.calls.Load(); calls != 0 {
+		t.Errorf("%d actions sent after cancelling", calls)
+	}
+}
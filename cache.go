@@ -1,6 +1,11 @@
 package goverseerr
 
-import "fmt"
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
 
 type CacheStats struct {
 	Hits   int `json:"hits"`
@@ -11,28 +16,81 @@ type CacheStats struct {
 }
 
 type Cache struct {
-	ID    string     `json:"id"`
+	ID    CacheID    `json:"id"`
 	Name  string     `json:"name"`
 	Stats CacheStats `json:"stats"`
 }
 
-func (o *Overseerr) GetCacheStats() ([]*Cache, error) {
-	var cache []*Cache
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
-		SetResult(&cache).Get("/settings/cache")
+type ImageCacheStats struct {
+	Size       int `json:"size"`
+	ImageCount int `json:"imageCount"`
+}
+
+// CacheSettings is the cache report from the server. Releases without an
+// image cache report only the API caches, as a bare list, which is decoded
+// into APICaches.
+type CacheSettings struct {
+	APICaches  []*Cache                   `json:"apiCaches"`
+	ImageCache map[string]ImageCacheStats `json:"imageCache"`
+}
+
+type cacheSettingsFields CacheSettings
+
+// CacheID identifies an API cache. It is an alias of string so IDs held in
+// plain strings can still be passed to FlushCache.
+type CacheID = string
+
+const (
+	CacheIDTMDB     CacheID = "tmdb"
+	CacheIDRadarr   CacheID = "radarr"
+	CacheIDSonarr   CacheID = "sonarr"
+	CacheIDRT       CacheID = "rt"
+	CacheIDIMDB     CacheID = "imdb"
+	CacheIDGitHub   CacheID = "github"
+	CacheIDPlexGUID CacheID = "plexguid"
+	CacheIDPlexTV   CacheID = "plextv"
+)
+
+// GetCacheSettings returns the stats for every API cache and the image
+// cache, keyed by image source (e.g. tmdb).
+func (o *Overseerr) GetCacheSettings() (*CacheSettings, error) {
+	return o.GetCacheSettingsContext(context.Background())
+}
+
+// GetCacheSettingsContext is GetCacheSettings with a context for the request.
+func (o *Overseerr) GetCacheSettingsContext(ctx context.Context) (*CacheSettings, error) {
+	var settings CacheSettings
+	resp, err := o.requestContext(ctx, "cache.list").
+		SetResult(&settings).Get("/settings/cache")
 	if err != nil {
 		return nil, err
 	}
 	if resp.StatusCode() != 200 {
 		return nil, fmt.Errorf("received non-200 status code (%d)", resp.StatusCode())
 	}
-	return cache, nil
+	return &settings, nil
+}
+
+// GetCacheStats returns the stats for every API cache, whichever shape the
+// server reports them in.
+func (o *Overseerr) GetCacheStats() ([]*Cache, error) {
+	settings, err := o.GetCacheSettings()
+	if err != nil {
+		return nil, err
+	}
+	return settings.APICaches, nil
+}
+
+func (o *Overseerr) GetImageCacheStats() (map[string]ImageCacheStats, error) {
+	settings, err := o.GetCacheSettings()
+	if err != nil {
+		return nil, err
+	}
+	return settings.ImageCache, nil
 }
 
-func (o *Overseerr) FlushCache(cacheID string) error {
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("cacheID", cacheID).
+func (o *Overseerr) FlushCache(cacheID CacheID) error {
+	resp, err := o.request("cache.flush").SetPathParam("cacheID", string(cacheID)).
 		Post("/settings/cache/{cacheID}/flush")
 	if err != nil {
 		return err
@@ -42,3 +100,52 @@ func (o *Overseerr) FlushCache(cacheID string) error {
 	}
 	return nil
 }
+
+// FlushAllCaches flushes every API cache the server reports, stopping at
+// the first failure.
+func (o *Overseerr) FlushAllCaches() error {
+	caches, err := o.GetCacheStats()
+	if err != nil {
+		return err
+	}
+	for _, cache := range caches {
+		if err := o.FlushCache(cache.ID); err != nil {
+			return fmt.Errorf("failed to flush cache %s: %w", cache.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *CacheSettings) UnmarshalJSON(data []byte) error {
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '[' {
+		*s = CacheSettings{}
+		return json.Unmarshal(data, &s.APICaches)
+	}
+	return json.Unmarshal(data, (*cacheSettingsFields)(s))
+}
+
+// HitRatio returns the fraction of lookups that were hits, or 0 if the cache
+// has not been used.
+func (s CacheStats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// Sub returns the change from a previous snapshot. Hit and miss counters
+// reset when a cache is flushed, in which case the current values are
+// returned as the delta.
+func (s CacheStats) Sub(previous CacheStats) CacheStats {
+	if s.Hits < previous.Hits || s.Misses < previous.Misses {
+		previous.Hits, previous.Misses = 0, 0
+	}
+	return CacheStats{
+		Hits:   s.Hits - previous.Hits,
+		Misses: s.Misses - previous.Misses,
+		Keys:   s.Keys - previous.Keys,
+		KSize:  s.KSize - previous.KSize,
+		VSize:  s.VSize - previous.VSize,
+	}
+}
@@ -0,0 +1,58 @@
+package goverseerr
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetCacheStatsShapes(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		images int
+	}{
+		{
+			name:   "settings",
+			body:   `{"apiCaches": [{"id": "tmdb", "name": "TMDb API", "stats": {"hits": 3, "misses": 1, "keys": 2}}], "imageCache": {"tmdb": {"size": 2048, "imageCount": 4}}}`,
+			images: 1,
+		},
+		{
+			name: "list",
+			body: `[{"id": "tmdb", "name": "TMDb API", "stats": {"hits": 3, "misses": 1, "keys": 2}}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var flushed string
+			o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					flushed = r.URL.Path
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				w.Write([]byte(tt.body))
+			})
+			caches, err := o.GetCacheStats()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(caches) != 1 || caches[0].ID != CacheIDTMDB || caches[0].Stats.HitRatio() != 0.75 {
+				t.Fatalf("caches = %+v, want the tmdb cache", caches)
+			}
+			images, err := o.GetImageCacheStats()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(images) != tt.images {
+				t.Errorf("image caches = %v, want %d", images, tt.images)
+			}
+			var id string = caches[0].ID
+			if err := o.FlushCache(id); err != nil {
+				t.Fatal(err)
+			}
+			if flushed != "/api/v1/settings/cache/tmdb/flush" {
+				t.Errorf("flushed %q, want the tmdb cache", flushed)
+			}
+		})
+	}
+}
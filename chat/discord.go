@@ -0,0 +1,77 @@
+package chat
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Limits Discord places on an embed, in characters unless noted.
+const (
+	discordMaxFields      int = 25
+	discordMaxTitle       int = 256
+	discordMaxDescription int = 4096
+	discordMaxFieldName   int = 256
+	discordMaxFieldValue  int = 1024
+	discordMaxFooter      int = 2048
+)
+
+type discordPayload struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title,omitempty"`
+	URL         string         `json:"url,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields,omitempty"`
+	Thumbnail   *discordImage  `json:"thumbnail,omitempty"`
+	Footer      *discordFooter `json:"footer,omitempty"`
+	Timestamp   string         `json:"timestamp,omitempty"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+type discordImage struct {
+	URL string `json:"url"`
+}
+
+type discordFooter struct {
+	Text string `json:"text"`
+}
+
+// Discord encodes the message as a Discord webhook payload with one embed.
+// Text is truncated to Discord's limits, empty fields are skipped and fields
+// beyond the limit of 25 are dropped.
+func (m Message) Discord() ([]byte, error) {
+	embed := discordEmbed{
+		Title:       truncate(m.Title, discordMaxTitle),
+		URL:         m.URL,
+		Description: truncate(m.Description, discordMaxDescription),
+		Color:       m.Color,
+	}
+	for idx, field := range m.fields() {
+		if idx == discordMaxFields {
+			break
+		}
+		embed.Fields = append(embed.Fields, discordField{
+			Name:   truncate(field.Name, discordMaxFieldName),
+			Value:  truncate(field.Value, discordMaxFieldValue),
+			Inline: field.Inline,
+		})
+	}
+	if m.ImageURL != "" {
+		embed.Thumbnail = &discordImage{URL: m.ImageURL}
+	}
+	if m.Footer != "" {
+		embed.Footer = &discordFooter{Text: truncate(m.Footer, discordMaxFooter)}
+	}
+	if !m.Timestamp.IsZero() {
+		embed.Timestamp = m.Timestamp.UTC().Format(time.RFC3339)
+	}
+	return json.Marshal(discordPayload{Embeds: []discordEmbed{embed}})
+}
@@ -0,0 +1,125 @@
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/willfantom/goverseerr"
+)
+
+func TestSearchResultsCapped(t *testing.T) {
+	results := &goverseerr.SearchResults{TotalResults: 30}
+	for idx := 0; idx < 30; idx++ {
+		results.Results = append(results.Results, goverseerr.GenericSearchResult{
+			MediaType:   goverseerr.MediaTypeMovie,
+			MovieResult: goverseerr.MovieResult{Title: fmt.Sprintf("Movie %d", idx)},
+		})
+	}
+	for _, limit := range []int{0, 30} {
+		msg := FromSearchResults("movie", results, limit)
+		if len(msg.Fields) != MaxSearchResults || msg.Footer != "Showing 25 of 30 results" {
+			t.Errorf("limit %d: %d fields with footer %q, want %d", limit, len(msg.Fields), msg.Footer, MaxSearchResults)
+		}
+	}
+}
+
+func TestFieldLimits(t *testing.T) {
+	var msg Message
+	for idx := 0; idx < 30; idx++ {
+		msg.Fields = append(msg.Fields, Field{Name: fmt.Sprintf("Field %d", idx), Value: "value"})
+	}
+
+	data, err := msg.Slack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var slack slackPayload
+	if err := json.Unmarshal(data, &slack); err != nil {
+		t.Fatal(err)
+	}
+	total := 0
+	for _, block := range slack.Blocks {
+		if len(block.Fields) > slackMaxFields {
+			t.Errorf("slack section has %d fields, limit is %d", len(block.Fields), slackMaxFields)
+		}
+		total += len(block.Fields)
+	}
+	if total != 30 {
+		t.Errorf("slack payload has %d fields, want all 30", total)
+	}
+
+	data, err = msg.Discord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var discord discordPayload
+	if err := json.Unmarshal(data, &discord); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(discord.Embeds[0].Fields); n != discordMaxFields {
+		t.Errorf("discord embed has %d fields, want %d", n, discordMaxFields)
+	}
+}
+
+func TestTextLimits(t *testing.T) {
+	msg := Message{
+		Title:       strings.Repeat("t", 500),
+		URL:         "https://example.com",
+		Description: strings.Repeat("<d>", 2000),
+		Fields:      []Field{{Name: strings.Repeat("n", 300), Value: strings.Repeat("v", 3000)}, {Name: "Empty"}},
+	}
+
+	data, err := msg.Discord()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var discord discordPayload
+	if err := json.Unmarshal(data, &discord); err != nil {
+		t.Fatal(err)
+	}
+	embed := discord.Embeds[0]
+	lengths := map[string][2]int{
+		"discord title":       {utf8.RuneCountInString(embed.Title), discordMaxTitle},
+		"discord description": {utf8.RuneCountInString(embed.Description), discordMaxDescription},
+		"discord field name":  {utf8.RuneCountInString(embed.Fields[0].Name), discordMaxFieldName},
+		"discord field value": {utf8.RuneCountInString(embed.Fields[0].Value), discordMaxFieldValue},
+	}
+	if len(embed.Fields) != 1 {
+		t.Errorf("discord embed has %d fields, want the empty one skipped", len(embed.Fields))
+	}
+
+	data, err = msg.Slack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var slack slackPayload
+	if err := json.Unmarshal(data, &slack); err != nil {
+		t.Fatal(err)
+	}
+	section := slack.Blocks[1]
+	lengths["slack header"] = [2]int{utf8.RuneCountInString(slack.Blocks[0].Text.Text), slackMaxHeader}
+	lengths["slack section"] = [2]int{utf8.RuneCountInString(section.Text.Text), slackMaxSectionText}
+	lengths["slack field"] = [2]int{utf8.RuneCountInString(section.Fields[0].Text), slackMaxFieldText}
+	if !strings.HasSuffix(section.Text.Text, "\n<https://example.com|View>") {
+		t.Errorf("slack section lost its link: %q", section.Text.Text[len(section.Text.Text)-40:])
+	}
+	if len(section.Fields) != 1 {
+		t.Errorf("slack section has %d fields, want the empty one skipped", len(section.Fields))
+	}
+
+	for name, length := range lengths {
+		if length[0] != length[1] {
+			t.Errorf("%s is %d characters, want it truncated to %d", name, length[0], length[1])
+		}
+	}
+}
+
+func TestSearchResultsNil(t *testing.T) {
+	msg := FromSearchResults("nothing", nil, 5)
+	if len(msg.Fields) != 0 || msg.Footer != "Showing 0 of 0 results" {
+		t.Fatalf("message = %+v, want no results", msg)
+	}
+}
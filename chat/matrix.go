@@ -0,0 +1,49 @@
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+)
+
+type matrixContent struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format"`
+	FormattedBody string `json:"formatted_body"`
+}
+
+// MatrixHTML renders the message as Matrix flavoured HTML.
+func (m Message) MatrixHTML() string {
+	var b strings.Builder
+	title := html.EscapeString(m.Title)
+	if m.URL != "" {
+		title = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(m.URL), title)
+	}
+	b.WriteString(fmt.Sprintf(`<h4><font color="#%06X">▌</font>%s</h4>`, m.Color, title))
+	if m.Description != "" {
+		b.WriteString("<p>" + html.EscapeString(m.Description) + "</p>")
+	}
+	if len(m.Fields) > 0 {
+		b.WriteString("<ul>")
+		for _, field := range m.Fields {
+			b.WriteString(fmt.Sprintf("<li><strong>%s:</strong> %s</li>", html.EscapeString(field.Name), html.EscapeString(field.Value)))
+		}
+		b.WriteString("</ul>")
+	}
+	if m.Footer != "" {
+		b.WriteString("<p><em>" + html.EscapeString(m.Footer) + "</em></p>")
+	}
+	return b.String()
+}
+
+// Matrix encodes the message as the content of an m.room.message event.
+func (m Message) Matrix() ([]byte, error) {
+	return json.Marshal(matrixContent{
+		MsgType:       "m.notice",
+		Body:          m.PlainText(),
+		Format:        "org.matrix.custom.html",
+		FormattedBody: m.MatrixHTML(),
+	})
+}
@@ -0,0 +1,276 @@
+// Package chat builds chat platform payloads (Discord, Slack, Telegram and
+// Matrix) for Overseerr requests, media details and search results.
+package chat
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/willfantom/goverseerr"
+)
+
+// Status colours used as embed/attachment accents.
+const (
+	ColorPending    int = 0xF59E0B
+	ColorApproved   int = 0x6366F1
+	ColorDeclined   int = 0xDC2626
+	ColorAvailable  int = 0x16A34A
+	ColorPartial    int = 0x22C55E
+	ColorProcessing int = 0x8B5CF6
+	ColorUnknown    int = 0x6B7280
+)
+
+// Message is a platform agnostic rich message that can be encoded for each
+// supported chat platform.
+type Message struct {
+	Title       string
+	URL         string
+	Description string
+	ImageURL    string
+	Color       int
+	Fields      []Field
+	Footer      string
+	Timestamp   time.Time
+}
+
+type Field struct {
+	Name   string
+	Value  string
+	Inline bool
+}
+
+// RequestColor returns the accent colour for a request status.
+func RequestColor(status goverseerr.RequestStatus) int {
+	switch status {
+	case goverseerr.RequestStatusPending:
+		return ColorPending
+	case goverseerr.RequestStatusApproved:
+		return ColorApproved
+	case goverseerr.RequestStatusDeclined:
+		return ColorDeclined
+	case goverseerr.RequestStatusAvailable:
+		return ColorAvailable
+	default:
+		return ColorUnknown
+	}
+}
+
+// MediaColor returns the accent colour for a media status.
+func MediaColor(status goverseerr.MediaStatus) int {
+	switch status {
+	case goverseerr.MediaStatusPending:
+		return ColorPending
+	case goverseerr.MediaStatusProcessing:
+		return ColorProcessing
+	case goverseerr.MediaStatusPartial:
+		return ColorPartial
+	case goverseerr.MediaStatusAvailable:
+		return ColorAvailable
+	default:
+		return ColorUnknown
+	}
+}
+
+// FromRequest builds a message for a request. The friendly form is optional
+// and, when given, supplies the content title and release date.
+func FromRequest(req *goverseerr.MediaRequest, friendly *goverseerr.FriendlyMediaRequest) Message {
+	title := fmt.Sprintf("%s Request #%d", req.Media.MediaType.ToEmoji(), req.ID)
+	if friendly != nil && friendly.ContentTitle != "" {
+		title = fmt.Sprintf("%s %s", req.Media.MediaType.ToEmoji(), friendly.ContentTitle)
+		if friendly.ContentDate != "" {
+			title += fmt.Sprintf(" (%s)", yearOf(friendly.ContentDate))
+		}
+	}
+	msg := Message{
+		Title:     title,
+		URL:       req.Media.PlexURL,
+		Color:     RequestColor(req.Status),
+		Footer:    fmt.Sprintf("Request #%d", req.ID),
+		Timestamp: req.Created,
+	}
+	if req.Creator.Email != "" {
+		msg.Fields = append(msg.Fields, Field{Name: "Requested By", Value: req.Creator.Email, Inline: true})
+	}
+	msg.Fields = append(msg.Fields,
+		Field{Name: "Request Status", Value: fmt.Sprintf("%s %s", req.Status.ToEmoji(), req.Status.ToString()), Inline: true},
+		Field{Name: "Media Status", Value: fmt.Sprintf("%s %s", req.Media.Status.ToEmoji(), req.Media.Status.ToString()), Inline: true},
+	)
+	if req.IsUHD {
+		msg.Fields = append(msg.Fields, Field{Name: "Quality", Value: "4K", Inline: true})
+	}
+	return msg
+}
+
+// FromMovie builds a message for a movie. Rating is optional and typically
+// comes from GetMovieRatings.
+func FromMovie(movie *goverseerr.MovieDetails, rating *goverseerr.Rating) Message {
+	title := movie.Title
+	if movie.ReleaseDate != "" {
+		title += fmt.Sprintf(" (%s)", yearOf(movie.ReleaseDate))
+	}
+	msg := Message{
+		Title:       fmt.Sprintf("%s %s", goverseerr.MediaTypeMovie.ToEmoji(), title),
+		URL:         fmt.Sprintf("https://www.themoviedb.org/movie/%d", movie.ID),
+		Description: movie.Overview,
+		ImageURL:    posterURL(movie.PosterPath),
+		Color:       MediaColor(movie.MediaInfo.Status),
+		Fields: []Field{
+			{Name: "Status", Value: fmt.Sprintf("%s %s", movie.MediaInfo.Status.ToEmoji(), movie.MediaInfo.Status.ToString()), Inline: true},
+		},
+	}
+	if movie.Runtime > 0 {
+		msg.Fields = append(msg.Fields, Field{Name: "Runtime", Value: fmt.Sprintf("%d min", movie.Runtime), Inline: true})
+	}
+	if genres := genreNames(movie.Genres); genres != "" {
+		msg.Fields = append(msg.Fields, Field{Name: "Genres", Value: genres, Inline: true})
+	}
+	msg.Fields = append(msg.Fields, ratingFields(movie.VoteAverage, rating)...)
+	return msg
+}
+
+// FromTV builds a message for a tv show. Rating is optional and typically
+// comes from GetTVRatings.
+func FromTV(tv *goverseerr.TVDetails, rating *goverseerr.Rating) Message {
+	title := tv.Name
+	if tv.FirstAired != "" {
+		title += fmt.Sprintf(" (%s)", yearOf(tv.FirstAired))
+	}
+	msg := Message{
+		Title:       fmt.Sprintf("%s %s", goverseerr.MediaTypeTV.ToEmoji(), title),
+		URL:         fmt.Sprintf("https://www.themoviedb.org/tv/%d", tv.ID),
+		Description: tv.Overview,
+		Color:       MediaColor(tv.MediaInfo.Status),
+		Fields: []Field{
+			{Name: "Status", Value: fmt.Sprintf("%s %s", tv.MediaInfo.Status.ToEmoji(), tv.MediaInfo.Status.ToString()), Inline: true},
+			{Name: "Seasons", Value: fmt.Sprintf("%d", tv.SeasonCount), Inline: true},
+		},
+	}
+	if genres := genreNames(tv.Genres); genres != "" {
+		msg.Fields = append(msg.Fields, Field{Name: "Genres", Value: genres, Inline: true})
+	}
+	msg.Fields = append(msg.Fields, ratingFields(tv.VoteAverage, rating)...)
+	return msg
+}
+
+// MaxSearchResults is the most results FromSearchResults lists, so that every
+// result fits in a single Discord embed.
+const MaxSearchResults int = discordMaxFields
+
+// FromSearchResults builds a message listing up to limit search results. A
+// limit below 1 or above MaxSearchResults lists MaxSearchResults.
+func FromSearchResults(query string, results *goverseerr.SearchResults, limit int) Message {
+	msg := Message{
+		Title: fmt.Sprintf("🔍 Results for \"%s\"", query),
+		Color: ColorUnknown,
+	}
+	if results == nil {
+		results = &goverseerr.SearchResults{}
+	}
+	if limit < 1 || limit > MaxSearchResults {
+		limit = MaxSearchResults
+	}
+	limit = min(limit, len(results.Results))
+	for _, result := range results.Results[:limit] {
+		name := result.Title
+		if name == "" {
+			name = result.Name
+		}
+		date := result.ReleaseDate
+		if date == "" {
+			date = result.FirstAiredDate
+		}
+		if date != "" {
+			name += fmt.Sprintf(" (%s)", yearOf(date))
+		}
+		msg.Fields = append(msg.Fields, Field{
+			Name:  fmt.Sprintf("%s %s", result.MediaType.ToEmoji(), name),
+			Value: fmt.Sprintf("%s %s", result.MediaInfo.Status.ToEmoji(), result.MediaInfo.Status.ToString()),
+		})
+	}
+	msg.Footer = fmt.Sprintf("Showing %d of %d results", limit, results.TotalResults)
+	if limit > 0 {
+		msg.ImageURL = posterURL(results.Results[0].PosterPath)
+	}
+	return msg
+}
+
+// PlainText renders the message without any markup.
+func (m Message) PlainText() string {
+	var b strings.Builder
+	b.WriteString(m.Title)
+	if m.Description != "" {
+		b.WriteString("\n" + m.Description)
+	}
+	for _, field := range m.Fields {
+		b.WriteString(fmt.Sprintf("\n%s: %s", field.Name, field.Value))
+	}
+	if m.Footer != "" {
+		b.WriteString("\n" + m.Footer)
+	}
+	return b.String()
+}
+
+// fields returns the fields that have both a name and a value, as Discord
+// rejects empty ones.
+func (m Message) fields() []Field {
+	var fields []Field
+	for _, field := range m.Fields {
+		if field.Name != "" && field.Value != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// truncate shortens text to at most limit characters, ending it with an
+// ellipsis when cut.
+func truncate(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	if limit < 1 {
+		return ""
+	}
+	runes := []rune(text)
+	return string(runes[:limit-1]) + "…"
+}
+
+func ratingFields(voteAverage float64, rating *goverseerr.Rating) []Field {
+	var fields []Field
+	if voteAverage > 0 {
+		fields = append(fields, Field{Name: "TMDB", Value: fmt.Sprintf("%.1f/10", voteAverage), Inline: true})
+	}
+	if rating != nil {
+		if rating.CriticScore > 0 {
+			fields = append(fields, Field{Name: "Critics", Value: fmt.Sprintf("🍅 %d%%", rating.CriticScore), Inline: true})
+		}
+		if rating.AudienceScore > 0 {
+			fields = append(fields, Field{Name: "Audience", Value: fmt.Sprintf("🍿 %d%%", rating.AudienceScore), Inline: true})
+		}
+	}
+	return fields
+}
+
+func genreNames(genres []goverseerr.Genre) string {
+	names := make([]string, len(genres))
+	for idx, genre := range genres {
+		names[idx] = genre.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+func posterURL(path string) string {
+	if path == "" {
+		return ""
+	}
+	return goverseerr.PosterPathBase + path
+}
+
+func yearOf(date string) string {
+	if len(date) >= 4 {
+		return date[:4]
+	}
+	return date
+}
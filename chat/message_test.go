@@ -0,0 +1,135 @@
+package chat
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/willfantom/goverseerr"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func testMessages() map[string]Message {
+	request := &goverseerr.MediaRequest{
+		ID:      42,
+		Status:  goverseerr.RequestStatusApproved,
+		Created: time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC),
+		IsUHD:   true,
+		Media: goverseerr.MediaInfo{
+			MediaType: goverseerr.MediaTypeMovie,
+			Status:    goverseerr.MediaStatusProcessing,
+			PlexURL:   "https://app.plex.tv/desktop#!/server/abc/details?key=1",
+		},
+		Creator: goverseerr.User{Email: "user_one@example.com"},
+	}
+	friendly := &goverseerr.FriendlyMediaRequest{ContentTitle: "Kiki's Delivery Service", ContentDate: "1989-07-29"}
+	movie := &goverseerr.MovieDetails{
+		ID:          16859,
+		Title:       "Kiki's Delivery Service",
+		ReleaseDate: "1989-07-29",
+		Overview:    "A young witch, on her mandatory year of independent life, finds fitting into a new community difficult <while> she supports herself by running an air courier service.",
+		PosterPath:  "/7nO5DUMnGUuXrA4r2h6ESOKQRrx.jpg",
+		Runtime:     103,
+		Genres:      []goverseerr.Genre{{Name: "Animation"}, {Name: "Family"}},
+		VoteAverage: 7.8,
+		MediaInfo:   goverseerr.MediaInfo{Status: goverseerr.MediaStatusAvailable},
+	}
+	tv := &goverseerr.TVDetails{
+		ID:          1396,
+		Name:        "Breaking Bad",
+		FirstAired:  "2008-01-20",
+		Overview:    "Walter White, a chemistry teacher (and family man) turns to a life of crime.",
+		SeasonCount: 5,
+		Genres:      []goverseerr.Genre{{Name: "Drama"}, {Name: "Crime"}},
+		VoteAverage: 8.9,
+		MediaInfo:   goverseerr.MediaInfo{Status: goverseerr.MediaStatusPartial},
+	}
+	search := &goverseerr.SearchResults{TotalResults: 2}
+	search.Results = append(search.Results,
+		goverseerr.GenericSearchResult{
+			MediaType:   goverseerr.MediaTypeMovie,
+			PosterPath:  "/poster.jpg",
+			MovieResult: goverseerr.MovieResult{Title: "Spirited Away", ReleaseDate: "2001-07-20"},
+			MediaInfo:   goverseerr.MediaInfo{Status: goverseerr.MediaStatusAvailable},
+		},
+		goverseerr.GenericSearchResult{
+			MediaType: goverseerr.MediaTypeTV,
+			Name:      "Avatar: The Last Airbender",
+			TVResult:  goverseerr.TVResult{FirstAiredDate: "2005-02-21"},
+		},
+	)
+	anonymous := *request
+	anonymous.Creator = goverseerr.User{}
+	longTitle := &goverseerr.FriendlyMediaRequest{ContentTitle: strings.Repeat("Very Long Title ", 20), ContentDate: "1989-07-29"}
+	longMovie := *movie
+	longMovie.Title = strings.Repeat("Long ", 60)
+	longMovie.Overview = strings.Repeat("An overview that keeps going & going. ", 120)
+	many := &goverseerr.SearchResults{TotalResults: 120}
+	for idx := 0; idx < 30; idx++ {
+		many.Results = append(many.Results, goverseerr.GenericSearchResult{
+			MediaType:   goverseerr.MediaTypeMovie,
+			MovieResult: goverseerr.MovieResult{Title: fmt.Sprintf("Movie %d", idx)},
+		})
+	}
+	return map[string]Message{
+		"request_anonymous": FromRequest(&anonymous, longTitle),
+		"movie_long":        FromMovie(&longMovie, nil),
+		"search_many":       FromSearchResults("movie", many, 0),
+		"request":           FromRequest(request, friendly),
+		"movie":             FromMovie(movie, &goverseerr.Rating{CriticScore: 98, AudienceScore: 96}),
+		"tv":                FromTV(tv, nil),
+		"search":            FromSearchResults("ghibli & friends", search, 0),
+	}
+}
+
+func TestGolden(t *testing.T) {
+	encoders := map[string]func(Message) ([]byte, error){
+		"discord.json":  func(m Message) ([]byte, error) { return indentJSON(m.Discord()) },
+		"slack.json":    func(m Message) ([]byte, error) { return indentJSON(m.Slack()) },
+		"matrix.json":   func(m Message) ([]byte, error) { return indentJSON(m.Matrix()) },
+		"telegram.md":   func(m Message) ([]byte, error) { return []byte(m.TelegramMarkdownV2()), nil },
+		"telegram.html": func(m Message) ([]byte, error) { return []byte(m.TelegramHTML()), nil },
+	}
+	for name, msg := range testMessages() {
+		for ext, encode := range encoders {
+			golden := filepath.Join("testdata", fmt.Sprintf("%s.%s.golden", name, ext))
+			t.Run(golden, func(t *testing.T) {
+				got, err := encode(msg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if *update {
+					if err := os.WriteFile(golden, got, 0o644); err != nil {
+						t.Fatal(err)
+					}
+				}
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatalf("%v (run go test -update to create it)", err)
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("output differs from %s:\n%s", golden, got)
+				}
+			})
+		}
+	}
+}
+
+func indentJSON(data []byte, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
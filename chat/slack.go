@@ -0,0 +1,102 @@
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+type slackPayload struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type      string      `json:"type"`
+	Text      *slackText  `json:"text,omitempty"`
+	Fields    []slackText `json:"fields,omitempty"`
+	Accessory *slackImage `json:"accessory,omitempty"`
+	Elements  []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type  string `json:"type"`
+	Text  string `json:"text"`
+	Emoji bool   `json:"emoji,omitempty"`
+}
+
+type slackImage struct {
+	Type     string `json:"type"`
+	ImageURL string `json:"image_url"`
+	AltText  string `json:"alt_text"`
+}
+
+// Limits Slack places on blocks, in characters unless noted.
+const (
+	slackMaxFields      int = 10
+	slackMaxHeader      int = 150
+	slackMaxSectionText int = 3000
+	slackMaxFieldText   int = 2000
+	slackMaxAltText     int = 2000
+)
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// Slack encodes the message as a Slack Block Kit payload. Fields are split
+// across sections as Slack allows at most 10 per section, empty fields are
+// skipped and text is truncated to Slack's limits.
+func (m Message) Slack() ([]byte, error) {
+	payload := slackPayload{Text: m.Title}
+	payload.Blocks = append(payload.Blocks, slackBlock{
+		Type: "header",
+		Text: &slackText{Type: "plain_text", Text: truncate(m.Title, slackMaxHeader), Emoji: true},
+	})
+	section := slackBlock{Type: "section"}
+	body := slackEscaper.Replace(m.Description)
+	if m.URL != "" {
+		link := fmt.Sprintf("<%s|View>", m.URL)
+		body = truncate(body, slackMaxSectionText-utf8.RuneCountInString(link)-1)
+		if body == "" {
+			body = link
+		} else {
+			body += "\n" + link
+		}
+	}
+	body = truncate(body, slackMaxSectionText)
+	if body != "" {
+		section.Text = &slackText{Type: "mrkdwn", Text: body}
+	}
+	var fields []slackText
+	for _, field := range m.fields() {
+		text := fmt.Sprintf("*%s*\n%s", slackEscaper.Replace(field.Name), slackEscaper.Replace(field.Value))
+		fields = append(fields, slackText{Type: "mrkdwn", Text: truncate(text, slackMaxFieldText)})
+	}
+	if len(fields) > slackMaxFields {
+		section.Fields, fields = fields[:slackMaxFields], fields[slackMaxFields:]
+	} else {
+		section.Fields, fields = fields, nil
+	}
+	if m.ImageURL != "" {
+		section.Accessory = &slackImage{Type: "image", ImageURL: m.ImageURL, AltText: truncate(m.Title, slackMaxAltText)}
+	}
+	if section.Text == nil && len(section.Fields) == 0 {
+		section.Text = &slackText{Type: "mrkdwn", Text: " "}
+	}
+	payload.Blocks = append(payload.Blocks, section)
+	for len(fields) > 0 {
+		n := len(fields)
+		if n > slackMaxFields {
+			n = slackMaxFields
+		}
+		payload.Blocks = append(payload.Blocks, slackBlock{Type: "section", Fields: fields[:n]})
+		fields = fields[n:]
+	}
+	if m.Footer != "" {
+		payload.Blocks = append(payload.Blocks, slackBlock{
+			Type:     "context",
+			Elements: []slackText{{Type: "mrkdwn", Text: slackEscaper.Replace(m.Footer)}},
+		})
+	}
+	return json.Marshal(payload)
+}
@@ -0,0 +1,65 @@
+package chat
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+var markdownV2Escaper = strings.NewReplacer(
+	`\`, `\\`, "_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`,
+	"~", `\~`, "`", "\\`", ">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`, "=", `\=`,
+	"|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`, "!", `\!`,
+)
+
+// EscapeMarkdownV2 escapes text for use in a Telegram MarkdownV2 message.
+func EscapeMarkdownV2(text string) string {
+	return markdownV2Escaper.Replace(text)
+}
+
+// TelegramMarkdownV2 renders the message using Telegram's MarkdownV2 parse
+// mode.
+func (m Message) TelegramMarkdownV2() string {
+	var b strings.Builder
+	title := "*" + EscapeMarkdownV2(m.Title) + "*"
+	if m.URL != "" {
+		title = fmt.Sprintf("[%s](%s)", title, strings.NewReplacer(`\`, `\\`, ")", `\)`).Replace(m.URL))
+	}
+	b.WriteString(title)
+	if m.Description != "" {
+		b.WriteString("\n\n" + EscapeMarkdownV2(m.Description))
+	}
+	if len(m.Fields) > 0 {
+		b.WriteString("\n")
+	}
+	for _, field := range m.Fields {
+		b.WriteString(fmt.Sprintf("\n*%s:* %s", EscapeMarkdownV2(field.Name), EscapeMarkdownV2(field.Value)))
+	}
+	if m.Footer != "" {
+		b.WriteString("\n\n_" + EscapeMarkdownV2(m.Footer) + "_")
+	}
+	return b.String()
+}
+
+// TelegramHTML renders the message using Telegram's HTML parse mode.
+func (m Message) TelegramHTML() string {
+	var b strings.Builder
+	title := "<b>" + html.EscapeString(m.Title) + "</b>"
+	if m.URL != "" {
+		title = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(m.URL), title)
+	}
+	b.WriteString(title)
+	if m.Description != "" {
+		b.WriteString("\n\n" + html.EscapeString(m.Description))
+	}
+	if len(m.Fields) > 0 {
+		b.WriteString("\n")
+	}
+	for _, field := range m.Fields {
+		b.WriteString(fmt.Sprintf("\n<b>%s:</b> %s", html.EscapeString(field.Name), html.EscapeString(field.Value)))
+	}
+	if m.Footer != "" {
+		b.WriteString("\n\n<i>" + html.EscapeString(m.Footer) + "</i>")
+	}
+	return b.String()
+}
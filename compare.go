@@ -0,0 +1,204 @@
+package goverseerr
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type DriftKind string
+
+const (
+	// DriftAdded items only exist on the second instance.
+	DriftAdded DriftKind = "added"
+	// DriftRemoved items only exist on the first instance.
+	DriftRemoved DriftKind = "removed"
+	DriftChanged DriftKind = "changed"
+)
+
+// Drift is an item that differs between two instances. Diffs are from the
+// first instance to the second.
+type Drift struct {
+	Section SnapshotSection
+	Name    string
+	Kind    DriftKind
+	Diffs   []FieldDiff
+}
+
+type DriftReport struct {
+	Drifts []Drift
+}
+
+// compareSections is the order sections are compared and reported in.
+var compareSections = []SnapshotSection{
+	SnapshotSectionMain,
+	SnapshotSectionPlex,
+	SnapshotSectionRadarr,
+	SnapshotSectionSonarr,
+	SnapshotSectionNotifications,
+	SnapshotSectionJobs,
+	SnapshotSectionUsers,
+}
+
+// Compare fetches the given sections from both instances and reports where
+// they differ. All sections are compared if none are given. Radarr and
+// Sonarr servers are matched by name, users by email and jobs by ID. Fields
+// that always differ between instances, such as the API key and server IDs,
+// are ignored.
+func Compare(ctx context.Context, a, b *Overseerr, sections ...SnapshotSection) (*DriftReport, error) {
+	if len(sections) == 0 {
+		sections = compareSections
+	}
+	var report DriftReport
+	for _, section := range sections {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		aItems, err := a.sectionItems(ctx, section)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", section, err)
+		}
+		bItems, err := b.sectionItems(ctx, section)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", section, err)
+		}
+		report.Drifts = append(report.Drifts, compareItems(section, aItems, bItems)...)
+	}
+	return &report, nil
+}
+
+// Empty reports whether the instances matched.
+func (r *DriftReport) Empty() bool {
+	return len(r.Drifts) == 0
+}
+
+// String renders the report with secrets masked.
+func (r *DriftReport) String() string {
+	if r.Empty() {
+		return "no differences"
+	}
+	var b strings.Builder
+	for _, drift := range r.Drifts {
+		fmt.Fprintf(&b, "%s %s", drift.Kind, drift.Section)
+		if drift.Name != "" {
+			fmt.Fprintf(&b, " %q", drift.Name)
+		}
+		b.WriteString("\n")
+		for _, diff := range drift.Diffs {
+			fmt.Fprintf(&b, "  %s\n", diff.Masked())
+		}
+	}
+	return b.String()
+}
+
+// sectionItems fetches a section as generic maps keyed by the name items are
+// matched on. Single item sections use an empty name.
+func (o *Overseerr) sectionItems(ctx context.Context, section SnapshotSection) (map[string]map[string]any, error) {
+	items := make(map[string]map[string]any)
+	add := func(name string, v any, ignore ...string) error {
+		m, err := toMap(v)
+		if err != nil {
+			return err
+		}
+		for _, field := range ignore {
+			delete(m, field)
+		}
+		items[name] = m
+		return nil
+	}
+	switch section {
+	case SnapshotSectionMain:
+		settings, err := o.GetMainSettings()
+		if err != nil {
+			return nil, err
+		}
+		return items, add("", settings, "apiKey")
+	case SnapshotSectionPlex:
+		settings, err := o.GetPlexSettings()
+		if err != nil {
+			return nil, err
+		}
+		return items, add("", settings)
+	case SnapshotSectionRadarr:
+		servers, err := o.GetRadarrSettings()
+		if err != nil {
+			return nil, err
+		}
+		for _, server := range servers {
+			if err := add(server.Name, server, "id"); err != nil {
+				return nil, err
+			}
+		}
+	case SnapshotSectionSonarr:
+		servers, err := o.GetSonarrSettings()
+		if err != nil {
+			return nil, err
+		}
+		for _, server := range servers {
+			if err := add(server.Name, server, "id"); err != nil {
+				return nil, err
+			}
+		}
+	case SnapshotSectionNotifications:
+		for _, agent := range NotificationAgents {
+			settings, err := GetNotificationSettings[map[string]any](o, agent)
+			if err != nil {
+				return nil, err
+			}
+			if err := add(string(agent), settings); err != nil {
+				return nil, err
+			}
+		}
+	case SnapshotSectionJobs:
+		jobs, err := o.GetJobs()
+		if err != nil {
+			return nil, err
+		}
+		for _, job := range jobs {
+			items[string(job.ID)] = map[string]any{"cronSchedule": job.CronSchedule}
+		}
+	case SnapshotSectionUsers:
+		users, err := o.backupUsers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			if err := add(user.Email, user, "email"); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown section: %s", section)
+	}
+	return items, nil
+}
+
+func compareItems(section SnapshotSection, a, b map[string]map[string]any) []Drift {
+	names := make([]string, 0, len(a)+len(b))
+	for name := range a {
+		names = append(names, name)
+	}
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var drifts []Drift
+	for _, name := range names {
+		aItem, inA := a[name]
+		bItem, inB := b[name]
+		switch {
+		case !inA:
+			drifts = append(drifts, Drift{Section: section, Name: name, Kind: DriftAdded, Diffs: fullDiff("", nil, bItem)})
+		case !inB:
+			drifts = append(drifts, Drift{Section: section, Name: name, Kind: DriftRemoved, Diffs: fullDiff("", aItem, nil)})
+		default:
+			if diffs := fullDiff("", aItem, bItem); len(diffs) > 0 {
+				drifts = append(drifts, Drift{Section: section, Name: name, Kind: DriftChanged, Diffs: diffs})
+			}
+		}
+	}
+	return drifts
+}
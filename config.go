@@ -0,0 +1,588 @@
+package goverseerr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Config is a declarative description of an Overseerr instance's settings.
+// Only the fields given are managed, anything omitted is left as it is on
+// the instance. Values of the form ${NAME} are read from the environment
+// when the document is loaded, so secrets need not be committed.
+type Config struct {
+	// Main holds /settings/main fields keyed by their JSON names.
+	Main map[string]any `json:"main,omitempty"`
+	// DefaultPermissions is shorthand for main.defaultPermissions.
+	DefaultPermissions *int           `json:"defaultPermissions,omitempty"`
+	Plex               *PlexConfig    `json:"plex,omitempty"`
+	Radarr             *ServersConfig `json:"radarr,omitempty"`
+	Sonarr             *ServersConfig `json:"sonarr,omitempty"`
+	// Notifications holds agent settings envelopes (enabled, types, options)
+	// keyed by agent.
+	Notifications map[NotificationAgent]map[string]any `json:"notifications,omitempty"`
+	// Jobs maps job IDs to cron schedules.
+	Jobs map[JobID]string `json:"jobs,omitempty"`
+}
+
+type PlexConfig struct {
+	// Settings holds /settings/plex fields keyed by their JSON names.
+	Settings map[string]any `json:"settings,omitempty"`
+	// Libraries lists the names of the libraries to enable, all others are
+	// disabled. Libraries are left untouched if empty.
+	Libraries []string `json:"libraries,omitempty"`
+}
+
+// ServersConfig declares Radarr or Sonarr servers, which are matched to
+// existing servers by name.
+type ServersConfig struct {
+	// Prune deletes servers that are not declared.
+	Prune   bool           `json:"prune,omitempty"`
+	Servers []ServerConfig `json:"servers,omitempty"`
+}
+
+// ServerConfig holds Radarr or Sonarr settings fields keyed by their JSON
+// names, "name" is required.
+type ServerConfig map[string]any
+
+type ChangeAction string
+
+const (
+	ChangeCreate ChangeAction = "create"
+	ChangeUpdate ChangeAction = "update"
+	ChangeDelete ChangeAction = "delete"
+)
+
+// Change is a single API call Apply will make.
+type Change struct {
+	Section string
+	Name    string
+	Action  ChangeAction
+	Diffs   []FieldDiff
+	apply   func(o *Overseerr) error
+}
+
+// Plan is the set of changes needed to bring an instance in line with a
+// Config.
+type Plan struct {
+	Changes []Change
+}
+
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// LoadConfig parses a YAML or JSON config document, substituting ${NAME}
+// references in string values with environment variables. References are
+// expanded after parsing, so values need no quoting or escaping. An error is
+// returned if any referenced variable is unset.
+func LoadConfig(data []byte) (*Config, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	missing := make(map[string]bool)
+	expanded, err := json.Marshal(expandEnv(doc, missing))
+	if err != nil {
+		return nil, err
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("config references unset environment variables: %s", strings.Join(sortedSet(missing), ", "))
+	}
+	var cfg Config
+	if err := json.Unmarshal(expanded, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// expandEnv substitutes environment variables into the string values of a
+// parsed document, recording any that are unset.
+func expandEnv(v any, missing map[string]bool) any {
+	switch v := v.(type) {
+	case string:
+		return envReference.ReplaceAllStringFunc(v, func(ref string) string {
+			name := envReference.FindStringSubmatch(ref)[1]
+			value, ok := os.LookupEnv(name)
+			if !ok {
+				missing[name] = true
+			}
+			return value
+		})
+	case map[string]any:
+		for key, value := range v {
+			v[key] = expandEnv(value, missing)
+		}
+	case []any:
+		for idx, value := range v {
+			v[idx] = expandEnv(value, missing)
+		}
+	}
+	return v
+}
+
+// Empty reports whether the plan has no changes.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String renders the plan for review with secrets masked.
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "no changes"
+	}
+	var b strings.Builder
+	for _, change := range p.Changes {
+		fmt.Fprintf(&b, "%s %s", change.Action, change.Section)
+		if change.Name != "" {
+			fmt.Fprintf(&b, " %q", change.Name)
+		}
+		b.WriteString("\n")
+		for _, diff := range change.Diffs {
+			fmt.Fprintf(&b, "  %s\n", diff.Masked())
+		}
+	}
+	return b.String()
+}
+
+// Plan compares the config with the live instance and returns the changes
+// Apply would make.
+func (o *Overseerr) Plan(ctx context.Context, cfg *Config) (*Plan, error) {
+	var plan Plan
+	steps := []func() error{
+		func() error { return o.planMain(cfg, &plan) },
+		func() error { return o.planPlex(cfg, &plan) },
+		func() error { return o.planRadarr(cfg, &plan) },
+		func() error { return o.planSonarr(cfg, &plan) },
+		func() error { return o.planNotifications(cfg, &plan) },
+		func() error { return o.planJobs(cfg, &plan) },
+	}
+	for _, step := range steps {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+	return &plan, nil
+}
+
+// Apply makes the plan's changes in order, stopping at the first failure.
+// The number of changes applied is returned alongside any error.
+func (o *Overseerr) Apply(ctx context.Context, plan *Plan) (int, error) {
+	for idx, change := range plan.Changes {
+		if err := ctx.Err(); err != nil {
+			return idx, err
+		}
+		if err := change.apply(o); err != nil {
+			name := change.Section
+			if change.Name != "" {
+				name += " " + change.Name
+			}
+			return idx, fmt.Errorf("failed to %s %s: %w", change.Action, name, err)
+		}
+	}
+	return len(plan.Changes), nil
+}
+
+func (o *Overseerr) planMain(cfg *Config, plan *Plan) error {
+	desired := make(map[string]any, len(cfg.Main)+1)
+	for key, value := range cfg.Main {
+		desired[key] = value
+	}
+	if cfg.DefaultPermissions != nil {
+		desired["defaultPermissions"] = *cfg.DefaultPermissions
+	}
+	if len(desired) == 0 {
+		return nil
+	}
+	live, err := o.GetMainSettings()
+	if err != nil {
+		return err
+	}
+	liveMap, err := toMap(live)
+	if err != nil {
+		return err
+	}
+	diffs := subsetDiff("", liveMap, desired)
+	if len(diffs) == 0 {
+		return nil
+	}
+	plan.Changes = append(plan.Changes, Change{
+		Section: "main",
+		Action:  ChangeUpdate,
+		Diffs:   diffs,
+		apply: func(o *Overseerr) error {
+			current, err := o.GetMainSettings()
+			if err != nil {
+				return err
+			}
+			var updated MainSettings
+			if err := overlayInto(current, desired, &updated); err != nil {
+				return err
+			}
+			_, err = o.UpdateMainSettings(updated)
+			return err
+		},
+	})
+	return nil
+}
+
+func (o *Overseerr) planPlex(cfg *Config, plan *Plan) error {
+	if cfg.Plex == nil {
+		return nil
+	}
+	if len(cfg.Plex.Settings) > 0 {
+		live, err := o.GetPlexSettings()
+		if err != nil {
+			return err
+		}
+		liveMap, err := toMap(live)
+		if err != nil {
+			return err
+		}
+		desired := cfg.Plex.Settings
+		if diffs := subsetDiff("", liveMap, desired); len(diffs) > 0 {
+			plan.Changes = append(plan.Changes, Change{
+				Section: "plex",
+				Action:  ChangeUpdate,
+				Diffs:   diffs,
+				apply: func(o *Overseerr) error {
+					current, err := o.GetPlexSettings()
+					if err != nil {
+						return err
+					}
+					var updated PlexSettings
+					if err := overlayInto(current, desired, &updated); err != nil {
+						return err
+					}
+					return o.UpdatePlexSettings(updated)
+				},
+			})
+		}
+	}
+	if len(cfg.Plex.Libraries) == 0 {
+		return nil
+	}
+	libraries, err := o.GetPlexLibraries()
+	if err != nil {
+		return err
+	}
+	missing := make(map[string]bool, len(cfg.Plex.Libraries))
+	for _, name := range cfg.Plex.Libraries {
+		missing[name] = true
+	}
+	var ids []string
+	var diffs []FieldDiff
+	for _, library := range libraries {
+		enable := containsString(cfg.Plex.Libraries, library.Name)
+		if enable {
+			ids = append(ids, library.ID)
+			delete(missing, library.Name)
+		}
+		if enable != library.Enabled {
+			diffs = append(diffs, FieldDiff{Path: "libraries." + library.Name, From: library.Enabled, To: enable})
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("plex libraries not found: %s", strings.Join(sortedSet(missing), ", "))
+	}
+	if len(diffs) == 0 {
+		return nil
+	}
+	plan.Changes = append(plan.Changes, Change{
+		Section: "plex libraries",
+		Action:  ChangeUpdate,
+		Diffs:   diffs,
+		apply: func(o *Overseerr) error {
+			_, err := o.EnablePlexLibraries(ids)
+			return err
+		},
+	})
+	return nil
+}
+
+func (o *Overseerr) planRadarr(cfg *Config, plan *Plan) error {
+	if cfg.Radarr == nil || (len(cfg.Radarr.Servers) == 0 && !cfg.Radarr.Prune) {
+		return nil
+	}
+	live, err := o.GetRadarrSettings()
+	if err != nil {
+		return err
+	}
+	servers := make([]liveServer, len(live))
+	for idx, server := range live {
+		servers[idx] = liveServer{name: server.Name, id: server.ID, settings: server}
+	}
+	return planServers("radarr", cfg.Radarr.Servers, servers, cfg.Radarr.Prune, plan, serverOps{
+		create: func(o *Overseerr, desired map[string]any) error {
+			var settings RadarrSettings
+			if err := fromMap(desired, &settings); err != nil {
+				return err
+			}
+			_, err := o.AddRadarr(settings)
+			return err
+		},
+		update: func(o *Overseerr, id int, current any, desired map[string]any) error {
+			var settings RadarrSettings
+			if err := overlayInto(current, desired, &settings); err != nil {
+				return err
+			}
+			return o.UpdateRadarrSettings(settings, id)
+		},
+		remove: func(o *Overseerr, id int) error {
+			return o.DeleteRadarr(id)
+		},
+	})
+}
+
+func (o *Overseerr) planSonarr(cfg *Config, plan *Plan) error {
+	if cfg.Sonarr == nil || (len(cfg.Sonarr.Servers) == 0 && !cfg.Sonarr.Prune) {
+		return nil
+	}
+	live, err := o.GetSonarrSettings()
+	if err != nil {
+		return err
+	}
+	servers := make([]liveServer, len(live))
+	for idx, server := range live {
+		servers[idx] = liveServer{name: server.Name, id: server.ID, settings: server}
+	}
+	return planServers("sonarr", cfg.Sonarr.Servers, servers, cfg.Sonarr.Prune, plan, serverOps{
+		create: func(o *Overseerr, desired map[string]any) error {
+			var settings SonarrSettings
+			if err := fromMap(desired, &settings); err != nil {
+				return err
+			}
+			_, err := o.AddSonarr(settings)
+			return err
+		},
+		update: func(o *Overseerr, id int, current any, desired map[string]any) error {
+			var settings SonarrSettings
+			if err := overlayInto(current, desired, &settings); err != nil {
+				return err
+			}
+			return o.UpdateSonarrSettings(settings, id)
+		},
+		remove: func(o *Overseerr, id int) error {
+			return o.DeleteSonarr(id)
+		},
+	})
+}
+
+type liveServer struct {
+	name     string
+	id       int
+	settings any
+}
+
+type serverOps struct {
+	create func(o *Overseerr, desired map[string]any) error
+	update func(o *Overseerr, id int, current any, desired map[string]any) error
+	remove func(o *Overseerr, id int) error
+}
+
+// planServers plans changes for Radarr or Sonarr servers, matching desired
+// servers to live ones by name.
+func planServers(section string, desired []ServerConfig, live []liveServer, prune bool, plan *Plan, ops serverOps) error {
+	byName := make(map[string]liveServer, len(live))
+	for _, server := range live {
+		byName[server.name] = server
+	}
+	declared := make(map[string]bool, len(desired))
+	for _, server := range desired {
+		name, _ := server["name"].(string)
+		if name == "" {
+			return fmt.Errorf("%s server config is missing a name", section)
+		}
+		declared[name] = true
+		fields := map[string]any(server)
+		server, ok := byName[name]
+		if !ok {
+			var diffs []FieldDiff
+			for _, key := range sortedKeys(fields) {
+				diffs = append(diffs, FieldDiff{Path: key, To: normalise(fields[key])})
+			}
+			plan.Changes = append(plan.Changes, Change{
+				Section: section,
+				Name:    name,
+				Action:  ChangeCreate,
+				Diffs:   diffs,
+				apply: func(o *Overseerr) error {
+					return ops.create(o, fields)
+				},
+			})
+			continue
+		}
+		liveMap, err := toMap(server.settings)
+		if err != nil {
+			return err
+		}
+		diffs := subsetDiff("", liveMap, fields)
+		if len(diffs) == 0 {
+			continue
+		}
+		plan.Changes = append(plan.Changes, Change{
+			Section: section,
+			Name:    name,
+			Action:  ChangeUpdate,
+			Diffs:   diffs,
+			apply: func(o *Overseerr) error {
+				return ops.update(o, server.id, server.settings, fields)
+			},
+		})
+	}
+	if !prune {
+		return nil
+	}
+	for _, server := range live {
+		if declared[server.name] {
+			continue
+		}
+		server := server
+		plan.Changes = append(plan.Changes, Change{
+			Section: section,
+			Name:    server.name,
+			Action:  ChangeDelete,
+			apply: func(o *Overseerr) error {
+				return ops.remove(o, server.id)
+			},
+		})
+	}
+	return nil
+}
+
+func (o *Overseerr) planNotifications(cfg *Config, plan *Plan) error {
+	agents := make([]string, 0, len(cfg.Notifications))
+	for agent := range cfg.Notifications {
+		agents = append(agents, string(agent))
+	}
+	sort.Strings(agents)
+	for _, name := range agents {
+		agent := NotificationAgent(name)
+		desired := cfg.Notifications[agent]
+		live, err := GetNotificationSettings[map[string]any](o, agent)
+		if err != nil {
+			return err
+		}
+		liveMap, err := toMap(live)
+		if err != nil {
+			return err
+		}
+		diffs := subsetDiff("", liveMap, desired)
+		if len(diffs) == 0 {
+			continue
+		}
+		plan.Changes = append(plan.Changes, Change{
+			Section: "notifications",
+			Name:    name,
+			Action:  ChangeUpdate,
+			Diffs:   diffs,
+			apply: func(o *Overseerr) error {
+				return o.updateNotificationAgent(agent, desired)
+			},
+		})
+	}
+	return nil
+}
+
+// updateNotificationAgent overlays the desired settings onto the agent's
+// current settings. The webhook agent is updated through WebhookOptions so
+// its payload template is sent JSON encoded.
+func (o *Overseerr) updateNotificationAgent(agent NotificationAgent, desired map[string]any) error {
+	current, err := GetNotificationSettings[map[string]any](o, agent)
+	if err != nil {
+		return err
+	}
+	if agent == NotificationAgentWebhook {
+		var updated NotificationAgentSettings[WebhookOptions]
+		if err := overlayInto(current, desired, &updated); err != nil {
+			return err
+		}
+		_, err = o.UpdateWebhookNotificationSettings(updated)
+		return err
+	}
+	var updated NotificationAgentSettings[map[string]any]
+	if err := overlayInto(current, desired, &updated); err != nil {
+		return err
+	}
+	_, err = UpdateNotificationSettings(o, agent, updated)
+	return err
+}
+
+func (o *Overseerr) planJobs(cfg *Config, plan *Plan) error {
+	if len(cfg.Jobs) == 0 {
+		return nil
+	}
+	jobs, err := o.GetJobs()
+	if err != nil {
+		return err
+	}
+	live := make(map[JobID]*Job, len(jobs))
+	for _, job := range jobs {
+		live[job.ID] = job
+	}
+	ids := make([]string, 0, len(cfg.Jobs))
+	for id := range cfg.Jobs {
+		ids = append(ids, string(id))
+	}
+	sort.Strings(ids)
+	for _, name := range ids {
+		id := JobID(name)
+		schedule := cfg.Jobs[id]
+		if _, err := ParseCronSchedule(schedule); err != nil {
+			return fmt.Errorf("job %s: %w", id, err)
+		}
+		job, ok := live[id]
+		if !ok {
+			return fmt.Errorf("job %s not found", id)
+		}
+		if job.CronSchedule == schedule {
+			continue
+		}
+		plan.Changes = append(plan.Changes, Change{
+			Section: "jobs",
+			Name:    name,
+			Action:  ChangeUpdate,
+			Diffs:   []FieldDiff{{Path: "cronSchedule", From: job.CronSchedule, To: schedule}},
+			apply: func(o *Overseerr) error {
+				_, err := o.UpdateJobSchedule(id, schedule)
+				return err
+			},
+		})
+	}
+	return nil
+}
+
+// overlayInto merges desired fields onto current and decodes the result into
+// out.
+func overlayInto(current any, desired map[string]any, out any) error {
+	currentMap, err := toMap(current)
+	if err != nil {
+		return err
+	}
+	return fromMap(overlay(currentMap, desired), out)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedSet(set map[string]bool) []string {
+	values := make([]string, 0, len(set))
+	for value := range set {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
@@ -0,0 +1,101 @@
+package goverseerr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigExpandsEnvAfterParsing(t *testing.T) {
+	values := map[string]string{
+		"TG_TOKEN":  "123:abc#def",
+		"INJECTED":  `x" injected: "y`,
+		"MULTILINE": "a: b\nc: d",
+	}
+	for name, value := range values {
+		t.Setenv(name, value)
+	}
+	cfg, err := LoadConfig([]byte(`
+main:
+  applicationTitle: ${INJECTED}
+notifications:
+  telegram:
+    options:
+      botAPI: ${TG_TOKEN}
+      chatId: "prefix-${MULTILINE}"
+sonarr:
+  prune: true
+  servers:
+    - name: sonarr
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Main["applicationTitle"]; got != values["INJECTED"] {
+		t.Errorf("applicationTitle = %q, want %q", got, values["INJECTED"])
+	}
+	if len(cfg.Main) != 1 {
+		t.Errorf("main has %d keys, want 1: %v", len(cfg.Main), cfg.Main)
+	}
+	options := cfg.Notifications[NotificationAgentTelegram]["options"].(map[string]any)
+	if got := options["botAPI"]; got != values["TG_TOKEN"] {
+		t.Errorf("botAPI = %q, want %q", got, values["TG_TOKEN"])
+	}
+	if got := options["chatId"]; got != "prefix-"+values["MULTILINE"] {
+		t.Errorf("chatId = %q, want %q", got, "prefix-"+values["MULTILINE"])
+	}
+	if cfg.Radarr != nil {
+		t.Errorf("radarr = %+v, want nil when undeclared", cfg.Radarr)
+	}
+	if cfg.Sonarr == nil || !cfg.Sonarr.Prune || len(cfg.Sonarr.Servers) != 1 {
+		t.Errorf("sonarr = %+v, want one server with prune", cfg.Sonarr)
+	}
+}
+
+func TestLoadConfigUnsetEnv(t *testing.T) {
+	_, err := LoadConfig([]byte("main:\n  applicationTitle: ${GOVERSEERR_UNSET_B}${GOVERSEERR_UNSET_A}\n"))
+	if err == nil || !strings.HasSuffix(err.Error(), "GOVERSEERR_UNSET_A, GOVERSEERR_UNSET_B") {
+		t.Fatalf("error = %v, want both unset variables listed", err)
+	}
+}
+
+func TestApplyWebhookEncodesPayload(t *testing.T) {
+	const template = `{"event": "{{event}}"}`
+	var posted map[string]any
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/settings/notifications/webhook" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodPost {
+			json.NewDecoder(r.Body).Decode(&posted)
+		}
+		// overseerr returns the template decoded
+		json.NewEncoder(w).Encode(map[string]any{
+			"enabled": false,
+			"types":   0,
+			"options": map[string]any{"webhookUrl": "http://old.example.com", "jsonPayload": template},
+		})
+	})
+	cfg := &Config{Notifications: map[NotificationAgent]map[string]any{
+		NotificationAgentWebhook: {"enabled": true, "options": map[string]any{"webhookUrl": "http://new.example.com"}},
+	}}
+	plan, err := o.Plan(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := o.Apply(context.Background(), plan); err != nil {
+		t.Fatal(err)
+	}
+	options, _ := posted["options"].(map[string]any)
+	payload, _ := options["jsonPayload"].(string)
+	var decoded string
+	if err := json.Unmarshal([]byte(payload), &decoded); err != nil || decoded != template {
+		t.Fatalf("jsonPayload = %q, want the template JSON encoded", payload)
+	}
+	if options["webhookUrl"] != "http://new.example.com" || posted["enabled"] != true {
+		t.Fatalf("posted = %v, want the desired changes", posted)
+	}
+}
@@ -0,0 +1,309 @@
+package goverseerr
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed Overseerr job schedule. Overseerr uses six field
+// expressions (second minute hour day-of-month month day-of-week), five
+// field expressions are also accepted and run on the zeroth second.
+type CronSchedule struct {
+	expr   string
+	second uint64
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+	// domStar and dowStar record unrestricted day fields, when both day
+	// fields are restricted a day matching either runs the job.
+	domStar bool
+	dowStar bool
+}
+
+// JobOverlap describes two jobs whose estimated run windows intersect.
+type JobOverlap struct {
+	First       JobID
+	Second      JobID
+	FirstStart  time.Time
+	SecondStart time.Time
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronSecond = cronField{name: "second", min: 0, max: 59}
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDOM    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	cronDOW = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// ParseCronSchedule parses and validates a cron expression.
+func ParseCronSchedule(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) == 5 {
+		fields = append([]string{"0"}, fields...)
+	}
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("cron expression must have 5 or 6 fields, got %d", len(fields))
+	}
+	schedule := CronSchedule{expr: expr}
+	var err error
+	if schedule.second, err = cronSecond.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if schedule.minute, err = cronMinute.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if schedule.hour, err = cronHour.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if schedule.dom, err = cronDOM.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if schedule.month, err = cronMonth.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	if schedule.dow, err = cronDOW.parse(fields[5]); err != nil {
+		return nil, err
+	}
+	// 7 is an alias for Sunday
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	schedule.domStar = isCronWildcard(fields[3])
+	schedule.dowStar = isCronWildcard(fields[5])
+	return &schedule, nil
+}
+
+func isCronWildcard(field string) bool {
+	return field == "*" || field == "?"
+}
+
+func (f cronField) parse(field string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepPart, f.name)
+			}
+		}
+		low, high := f.min, f.max
+		if !isCronWildcard(rangePart) {
+			lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = f.value(lowPart); err != nil {
+				return 0, err
+			}
+			high = low
+			if isRange {
+				if high, err = f.value(highPart); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				high = f.max
+			}
+		}
+		if low > high {
+			return 0, fmt.Errorf("invalid range %q in %s field", rangePart, f.name)
+		}
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (f cronField) all() uint64 {
+	var bits uint64
+	for v := f.min; v <= f.max; v++ {
+		bits |= 1 << uint(v)
+	}
+	return bits
+}
+
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %q in %s field (allowed %d-%d)", s, f.name, f.min, f.max)
+	}
+	return v, nil
+}
+
+func (s *CronSchedule) String() string {
+	return s.expr
+}
+
+// Next returns the first run time strictly after the given time, in the
+// time's location. Runs that fall in a skipped daylight saving hour do not
+// happen, and a schedule with a fixed hour only runs once in a repeated hour.
+// The zero time is returned if no run occurs within five years.
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Second).Add(time.Second)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = advanceTo(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc))
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = advanceTo(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc))
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 || s.repeatedHour(t) {
+			t = nextHour(t)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		if s.second&(1<<uint(t.Second())) == 0 {
+			t = t.Add(time.Second)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// nextHour returns the start of the next wall clock hour. It steps in
+// absolute time so it always moves forward across daylight saving changes.
+func nextHour(t time.Time) time.Time {
+	return t.Add(time.Hour - time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second)
+}
+
+// advanceTo returns next, or the next hour if a daylight saving change
+// normalised next to a time that is not after t.
+func advanceTo(t, next time.Time) time.Time {
+	if next.After(t) {
+		return next
+	}
+	return nextHour(t)
+}
+
+// repeatedHour reports whether t is in the second occurrence of an hour
+// repeated by a daylight saving change, for schedules with a fixed hour.
+func (s *CronSchedule) repeatedHour(t time.Time) bool {
+	if s.hour == cronHour.all() {
+		return false
+	}
+	earlier := t.Add(-time.Hour)
+	return earlier.Day() == t.Day() && earlier.Hour() == t.Hour()
+}
+
+// NextN returns the next n run times after the given time.
+func (s *CronSchedule) NextN(after time.Time, n int) []time.Time {
+	runs := make([]time.Time, 0, n)
+	for len(runs) < n {
+		after = s.Next(after)
+		if after.IsZero() {
+			break
+		}
+		runs = append(runs, after)
+	}
+	return runs
+}
+
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// GetServerLocation returns the time zone the Overseerr server schedules its
+// jobs in, falling back to UTC when the server does not report one.
+func (o *Overseerr) GetServerLocation() (*time.Location, error) {
+	about, err := o.GetAbout()
+	if err != nil {
+		return nil, err
+	}
+	if about.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(about.TimeZone)
+}
+
+// Schedule parses the job's cron schedule.
+func (j *Job) Schedule() (*CronSchedule, error) {
+	return ParseCronSchedule(j.CronSchedule)
+}
+
+// NextRuns returns the job's next n run times in the given location.
+func (j *Job) NextRuns(loc *time.Location, n int) ([]time.Time, error) {
+	schedule, err := j.Schedule()
+	if err != nil {
+		return nil, err
+	}
+	return schedule.NextN(time.Now().In(loc), n), nil
+}
+
+// FindJobOverlaps looks at each job's runs between now and the horizon and
+// reports pairs whose run windows intersect. The duration of each job's run
+// is taken from durations, or defaultDuration for jobs not in the map.
+func FindJobOverlaps(jobs []*Job, loc *time.Location, horizon, defaultDuration time.Duration, durations map[JobID]time.Duration) ([]JobOverlap, error) {
+	type window struct {
+		job        JobID
+		start, end time.Time
+	}
+	now := time.Now().In(loc)
+	end := now.Add(horizon)
+	var windows []window
+	for _, job := range jobs {
+		schedule, err := job.Schedule()
+		if err != nil {
+			return nil, fmt.Errorf("job %s: %w", job.ID, err)
+		}
+		duration, ok := durations[job.ID]
+		if !ok {
+			duration = defaultDuration
+		}
+		for run := schedule.Next(now); !run.IsZero() && run.Before(end); run = schedule.Next(run) {
+			windows = append(windows, window{job: job.ID, start: run, end: run.Add(duration)})
+		}
+	}
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i].start.Before(windows[j].start)
+	})
+	var overlaps []JobOverlap
+	for i := range windows {
+		for j := i + 1; j < len(windows) && windows[j].start.Before(windows[i].end); j++ {
+			if windows[i].job == windows[j].job {
+				continue
+			}
+			overlaps = append(overlaps, JobOverlap{
+				First:       windows[i].job,
+				Second:      windows[j].job,
+				FirstStart:  windows[i].start,
+				SecondStart: windows[j].start,
+			})
+		}
+	}
+	return overlaps, nil
+}
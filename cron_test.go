@@ -0,0 +1,180 @@
+package goverseerr
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr bool
+	}{
+		{expr: "0 0 5 * * *"},
+		{expr: "0 5 * * *"},
+		{expr: "*/15 * * * * ?"},
+		{expr: "0 0-30/10 1,13 * * *"},
+		{expr: "0 0 4 1 jan,JUL mon-fri"},
+		{expr: "0 0 0 * * 7"},
+		{expr: "* * * *", wantErr: true},
+		{expr: "0 0 0 0 * * * *", wantErr: true},
+		{expr: "60 * * * * *", wantErr: true},
+		{expr: "0 0 24 * * *", wantErr: true},
+		{expr: "0 0 0 32 * *", wantErr: true},
+		{expr: "0 0 0 * 13 *", wantErr: true},
+		{expr: "0 0 0 * * 8", wantErr: true},
+		{expr: "0 0 0 * * funday", wantErr: true},
+		{expr: "0 30-10 * * * *", wantErr: true},
+		{expr: "*/0 * * * * *", wantErr: true},
+		{expr: "*/x * * * * *", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseCronSchedule(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCronSchedule(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	at := func(loc *time.Location, value string) time.Time {
+		t.Helper()
+		parsed, err := time.ParseInLocation("2006-01-02 15:04:05", value, loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+	tests := []struct {
+		name  string
+		expr  string
+		loc   *time.Location
+		after string
+		want  []string
+	}{
+		{
+			name:  "five fields",
+			expr:  "30 4 * * *",
+			loc:   time.UTC,
+			after: "2026-05-01 04:30:00",
+			want:  []string{"2026-05-02 04:30:00 UTC", "2026-05-03 04:30:00 UTC"},
+		},
+		{
+			name:  "seconds step",
+			expr:  "*/20 * * * * *",
+			loc:   time.UTC,
+			after: "2026-05-01 00:00:50",
+			want:  []string{"2026-05-01 00:01:00 UTC", "2026-05-01 00:01:20 UTC"},
+		},
+		{
+			name:  "range with step",
+			expr:  "0 0-30/15 2 * * *",
+			loc:   time.UTC,
+			after: "2026-05-01 02:20:00",
+			want:  []string{"2026-05-01 02:30:00 UTC", "2026-05-02 02:00:00 UTC"},
+		},
+		{
+			name:  "named month and weekday",
+			expr:  "0 0 9 * feb MON",
+			loc:   time.UTC,
+			after: "2026-01-20 00:00:00",
+			want:  []string{"2026-02-02 09:00:00 UTC", "2026-02-09 09:00:00 UTC"},
+		},
+		{
+			name:  "day of month or day of week",
+			expr:  "0 0 0 13 * fri",
+			loc:   time.UTC,
+			after: "2026-03-01 00:00:00",
+			want:  []string{"2026-03-06 00:00:00 UTC", "2026-03-13 00:00:00 UTC", "2026-03-20 00:00:00 UTC"},
+		},
+		{
+			name:  "sunday as seven",
+			expr:  "0 0 0 * * 7",
+			loc:   time.UTC,
+			after: "2026-03-02 00:00:00",
+			want:  []string{"2026-03-08 00:00:00 UTC"},
+		},
+		{
+			name:  "day of month skips short months",
+			expr:  "0 0 0 31 * *",
+			loc:   time.UTC,
+			after: "2026-01-31 00:00:00",
+			want:  []string{"2026-03-31 00:00:00 UTC"},
+		},
+		{
+			name:  "spring forward skips the missing hour",
+			expr:  "0 30 2 * * *",
+			loc:   newYork,
+			after: "2026-03-07 03:00:00",
+			want:  []string{"2026-03-09 02:30:00 EDT"},
+		},
+		{
+			name:  "spring forward sunday midnight",
+			expr:  "0 0 0 * * 7",
+			loc:   newYork,
+			after: "2026-03-07 00:00:00",
+			want:  []string{"2026-03-08 00:00:00 EST", "2026-03-15 00:00:00 EDT"},
+		},
+		{
+			name:  "spring forward monthly weekday",
+			expr:  "0 0 4 1 * mon",
+			loc:   newYork,
+			after: "2026-03-07 00:00:00",
+			want:  []string{"2026-03-09 04:00:00 EDT"},
+		},
+		{
+			name:  "spring forward hourly",
+			expr:  "0 0 * * * *",
+			loc:   newYork,
+			after: "2026-03-08 01:30:00",
+			want:  []string{"2026-03-08 03:00:00 EDT", "2026-03-08 04:00:00 EDT"},
+		},
+		{
+			name:  "fall back runs a fixed hour once",
+			expr:  "0 30 1 * * *",
+			loc:   newYork,
+			after: "2026-11-01 00:00:00",
+			want:  []string{"2026-11-01 01:30:00 EDT", "2026-11-02 01:30:00 EST"},
+		},
+		{
+			name:  "fall back runs hourly schedules in both hours",
+			expr:  "0 0 * * * *",
+			loc:   newYork,
+			after: "2026-11-01 00:30:00",
+			want:  []string{"2026-11-01 01:00:00 EDT", "2026-11-01 01:00:00 EST", "2026-11-01 02:00:00 EST"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCronSchedule(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			runs := schedule.NextN(at(tt.loc, tt.after), len(tt.want))
+			if len(runs) != len(tt.want) {
+				t.Fatalf("got %d runs, want %d", len(runs), len(tt.want))
+			}
+			for idx, run := range runs {
+				if got := run.Format("2006-01-02 15:04:05 MST"); got != tt.want[idx] {
+					t.Errorf("run %d = %s, want %s", idx, got, tt.want[idx])
+				}
+			}
+		})
+	}
+}
+
+func TestCronScheduleNextNever(t *testing.T) {
+	schedule, err := ParseCronSchedule("0 0 0 30 feb *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := schedule.Next(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)); !next.IsZero() {
+		t.Fatalf("Next() = %s, want zero time", next)
+	}
+}
@@ -0,0 +1,205 @@
+package goverseerr
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const maskedValue string = "********"
+
+// FieldDiff is a single differing field. Path is dot separated, e.g.
+// "options.webhookUrl".
+type FieldDiff struct {
+	Path string
+	From any
+	To   any
+}
+
+// secretFieldHints are suffixes of field names holding credentials. Webhook
+// URLs are included as Discord, Slack and LunaSea embed a token in them.
+var secretFieldHints = []string{"apikey", "botapi", "password", "pass", "token", "secret", "authheader", "pgpprivatekey", "webhookurl"}
+
+// IsSecretField reports whether a settings field name looks like it holds a
+// credential.
+func IsSecretField(name string) bool {
+	name = strings.ToLower(name)
+	for _, hint := range secretFieldHints {
+		if strings.HasSuffix(name, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// Masked returns the diff with secret values replaced, including secrets
+// nested in object values.
+func (d FieldDiff) Masked() FieldDiff {
+	parts := strings.Split(d.Path, ".")
+	secret := IsSecretField(parts[len(parts)-1])
+	d.From = maskValue(d.From, secret)
+	d.To = maskValue(d.To, secret)
+	return d
+}
+
+func maskValue(v any, secret bool) any {
+	if v == nil {
+		return nil
+	}
+	if secret {
+		return maskedValue
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+	masked := make(map[string]any, len(m))
+	for key, value := range m {
+		masked[key] = maskValue(value, IsSecretField(key))
+	}
+	return masked
+}
+
+func (d FieldDiff) String() string {
+	return fmt.Sprintf("%s: %s -> %s", d.Path, formatDiffValue(d.From), formatDiffValue(d.To))
+}
+
+func formatDiffValue(v any) string {
+	if v == nil {
+		return "<unset>"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// toMap converts a JSON encodable value into its generic map form.
+func toMap(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// fromMap decodes a generic map into v.
+func fromMap(m map[string]any, v any) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// normalise round trips a value through JSON so values from different
+// sources (e.g. YAML ints and JSON floats) compare equal.
+func normalise(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var out any
+	if err := json.Unmarshal(data, &out); err != nil {
+		return v
+	}
+	return out
+}
+
+// overlay deep merges desired onto a copy of live.
+func overlay(live, desired map[string]any) map[string]any {
+	out := make(map[string]any, len(live))
+	for key, value := range live {
+		out[key] = value
+	}
+	for key, value := range desired {
+		desiredMap, desiredIsMap := value.(map[string]any)
+		liveMap, liveIsMap := out[key].(map[string]any)
+		if desiredIsMap && liveIsMap {
+			out[key] = overlay(liveMap, desiredMap)
+			continue
+		}
+		out[key] = value
+	}
+	return out
+}
+
+// subsetDiff reports the fields in desired whose values differ from live,
+// fields only present in live are ignored.
+func subsetDiff(prefix string, live, desired map[string]any) []FieldDiff {
+	var diffs []FieldDiff
+	for _, key := range sortedKeys(desired) {
+		path := joinPath(prefix, key)
+		desiredValue := normalise(desired[key])
+		liveValue, ok := live[key]
+		if !ok {
+			diffs = append(diffs, FieldDiff{Path: path, To: desiredValue})
+			continue
+		}
+		liveValue = normalise(liveValue)
+		desiredMap, desiredIsMap := desiredValue.(map[string]any)
+		liveMap, liveIsMap := liveValue.(map[string]any)
+		if desiredIsMap && liveIsMap {
+			diffs = append(diffs, subsetDiff(path, liveMap, desiredMap)...)
+			continue
+		}
+		if !reflect.DeepEqual(liveValue, desiredValue) {
+			diffs = append(diffs, FieldDiff{Path: path, From: liveValue, To: desiredValue})
+		}
+	}
+	return diffs
+}
+
+// fullDiff reports every field that differs between a and b.
+func fullDiff(prefix string, a, b map[string]any) []FieldDiff {
+	var diffs []FieldDiff
+	keys := sortedKeys(a)
+	for _, key := range sortedKeys(b) {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		path := joinPath(prefix, key)
+		aValue, inA := a[key]
+		bValue, inB := b[key]
+		aValue, bValue = normalise(aValue), normalise(bValue)
+		aMap, aIsMap := aValue.(map[string]any)
+		bMap, bIsMap := bValue.(map[string]any)
+		switch {
+		case aIsMap && bIsMap:
+			diffs = append(diffs, fullDiff(path, aMap, bMap)...)
+		case !inA:
+			diffs = append(diffs, FieldDiff{Path: path, To: bValue})
+		case !inB:
+			diffs = append(diffs, FieldDiff{Path: path, From: aValue})
+		case !reflect.DeepEqual(aValue, bValue):
+			diffs = append(diffs, FieldDiff{Path: path, From: aValue, To: bValue})
+		}
+	}
+	return diffs
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,73 @@
+package goverseerr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFieldDiffMaskedAgentSecrets(t *testing.T) {
+	secrets := map[NotificationAgent][]string{
+		NotificationAgentEmail:      {"authPass", "pgpPrivateKey", "pgpPassword"},
+		NotificationAgentDiscord:    {"webhookUrl"},
+		NotificationAgentSlack:      {"webhookUrl"},
+		NotificationAgentTelegram:   {"botAPI"},
+		NotificationAgentPushbullet: {"accessToken"},
+		NotificationAgentPushover:   {"accessToken", "userToken"},
+		NotificationAgentWebhook:    {"webhookUrl", "authHeader"},
+		NotificationAgentGotify:     {"token"},
+		NotificationAgentLunaSea:    {"webhookUrl"},
+	}
+	for agent, fields := range secrets {
+		for _, field := range fields {
+			diff := FieldDiff{Path: "options." + field, From: "old-secret", To: "new-secret"}.Masked()
+			if diff.From != maskedValue || diff.To != maskedValue {
+				t.Errorf("%s options.%s not masked: %s", agent, field, diff)
+			}
+		}
+	}
+}
+
+func TestFieldDiffMasked(t *testing.T) {
+	tests := []struct {
+		name string
+		diff FieldDiff
+		want string
+	}{
+		{
+			name: "api key",
+			diff: FieldDiff{Path: "apiKey", From: "abc", To: "def"},
+			want: `apiKey: "********" -> "********"`,
+		},
+		{
+			name: "unset side kept",
+			diff: FieldDiff{Path: "options.botAPI", To: "123:abc"},
+			want: `options.botAPI: <unset> -> "********"`,
+		},
+		{
+			name: "plain field",
+			diff: FieldDiff{Path: "options.chatId", From: "1", To: "2"},
+			want: `options.chatId: "1" -> "2"`,
+		},
+		{
+			name: "nested secrets",
+			diff: FieldDiff{Path: "options", To: map[string]any{"botAPI": "123:abc", "chatId": "1"}},
+			want: `options: <unset> -> {"botAPI":"********","chatId":"1"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.diff.Masked().String(); got != tt.want {
+				t.Errorf("Masked() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDriftReportStringMasksSecrets(t *testing.T) {
+	a := map[string]map[string]any{"telegram": {"options": map[string]any{"botAPI": "111:secret", "chatId": "1"}}}
+	b := map[string]map[string]any{"telegram": {"options": map[string]any{"botAPI": "222:secret", "chatId": "1"}}}
+	report := DriftReport{Drifts: compareItems(SnapshotSectionNotifications, a, b)}
+	if out := report.String(); strings.Contains(out, "secret") {
+		t.Fatalf("report leaks a secret:\n%s", out)
+	}
+}
@@ -7,8 +7,7 @@ func (o *Overseerr) DiscoverMovies(pageNumber int) (*SearchResults, error) {
 		return nil, fmt.Errorf("page number must be 1 or higher")
 	}
 	var results SearchResults
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetQueryParams(map[string]string{
+	resp, err := o.request("discover.movies").SetQueryParams(map[string]string{
 		"page":     fmt.Sprintf("%d", pageNumber),
 		"language": o.locale,
 	}).SetResult(&results).Get("/discover/movies")
@@ -26,8 +25,7 @@ func (o *Overseerr) DiscoverTV(pageNumber int) (*SearchResults, error) {
 		return nil, fmt.Errorf("page number must be 1 or higher")
 	}
 	var results SearchResults
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetQueryParams(map[string]string{
+	resp, err := o.request("discover.tv").SetQueryParams(map[string]string{
 		"page":     fmt.Sprintf("%d", pageNumber),
 		"language": o.locale,
 	}).SetResult(&results).Get("/discover/tv")
@@ -45,8 +43,7 @@ func (o *Overseerr) DiscoverMoviesByGenre(pageNumber, genreID int) (*SearchResul
 		return nil, fmt.Errorf("page number must be 1 or higher")
 	}
 	var results SearchResults
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetQueryParams(map[string]string{
+	resp, err := o.request("discover.movies.genre").SetQueryParams(map[string]string{
 		"page":     fmt.Sprintf("%d", pageNumber),
 		"language": o.locale,
 	}).SetPathParam("genreID", fmt.Sprintf("%d", genreID)).SetResult(&results).
@@ -65,8 +62,7 @@ func (o *Overseerr) DiscoverMoviesByStudio(pageNumber, studioID int) (*SearchRes
 		return nil, fmt.Errorf("page number must be 1 or higher")
 	}
 	var results SearchResults
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetQueryParams(map[string]string{
+	resp, err := o.request("discover.movies.studio").SetQueryParams(map[string]string{
 		"page":     fmt.Sprintf("%d", pageNumber),
 		"language": o.locale,
 	}).SetPathParam("studioID", fmt.Sprintf("%d", studioID)).SetResult(&results).
@@ -85,8 +81,7 @@ func (o *Overseerr) DiscoverUpcomingMovies(pageNumber int) (*SearchResults, erro
 		return nil, fmt.Errorf("page number must be 1 or higher")
 	}
 	var results SearchResults
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetQueryParams(map[string]string{
+	resp, err := o.request("discover.movies.upcoming").SetQueryParams(map[string]string{
 		"page":     fmt.Sprintf("%d", pageNumber),
 		"language": o.locale,
 	}).SetResult(&results).Get("/discover/movies/upcoming")
@@ -104,8 +99,7 @@ func (o *Overseerr) DiscoverTVByGenre(pageNumber, genreID int) (*SearchResults,
 		return nil, fmt.Errorf("page number must be 1 or higher")
 	}
 	var results SearchResults
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetQueryParams(map[string]string{
+	resp, err := o.request("discover.tv.genre").SetQueryParams(map[string]string{
 		"page":     fmt.Sprintf("%d", pageNumber),
 		"language": o.locale,
 	}).SetPathParam("genreID", fmt.Sprintf("%d", genreID)).SetResult(&results).
@@ -124,8 +118,7 @@ func (o *Overseerr) DiscoverTVByNetwork(pageNumber, networkID int) (*SearchResul
 		return nil, fmt.Errorf("page number must be 1 or higher")
 	}
 	var results SearchResults
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetQueryParams(map[string]string{
+	resp, err := o.request("discover.tv.network").SetQueryParams(map[string]string{
 		"page":     fmt.Sprintf("%d", pageNumber),
 		"language": o.locale,
 	}).SetPathParam("networkID", fmt.Sprintf("%d", networkID)).SetResult(&results).
@@ -144,8 +137,7 @@ func (o *Overseerr) DiscoverUpcomingTV(pageNumber int) (*SearchResults, error) {
 		return nil, fmt.Errorf("page number must be 1 or higher")
 	}
 	var results SearchResults
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetQueryParams(map[string]string{
+	resp, err := o.request("discover.tv.upcoming").SetQueryParams(map[string]string{
 		"page":     fmt.Sprintf("%d", pageNumber),
 		"language": o.locale,
 	}).SetResult(&results).Get("/discover/tv/upcoming")
@@ -163,8 +155,7 @@ func (o *Overseerr) DiscoverTrending(pageNumber int) (*SearchResults, error) {
 		return nil, fmt.Errorf("page number must be 1 or higher")
 	}
 	var results SearchResults
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetQueryParams(map[string]string{
+	resp, err := o.request("discover.trending").SetQueryParams(map[string]string{
 		"page":     fmt.Sprintf("%d", pageNumber),
 		"language": o.locale,
 	}).SetResult(&results).Get("/discover/trending")
@@ -0,0 +1,62 @@
+package goverseerr
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// unmarshalKeepingExtra decodes data into v, a pointer to a struct, and
+// returns any fields v has no json tag for so they can be written back.
+func unmarshalKeepingExtra(data []byte, v any) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	for _, name := range jsonFieldNames(reflect.TypeOf(v).Elem()) {
+		delete(all, name)
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+	return all, nil
+}
+
+// marshalWithExtra encodes v and merges in extra fields, fields known to v
+// take precedence.
+func marshalWithExtra(v any, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	for key, value := range extra {
+		if _, known := all[key]; !known {
+			all[key] = value
+		}
+	}
+	return json.Marshal(all)
+}
+
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+	for idx := 0; idx < t.NumField(); idx++ {
+		field := t.Field(idx)
+		tag := field.Tag.Get("json")
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
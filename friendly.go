@@ -0,0 +1,208 @@
+package goverseerr
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDetailsCacheTTL   time.Duration = 10 * time.Minute
+	defaultEnrichConcurrency int           = 8
+)
+
+type detailsKey struct {
+	mediaType MediaType
+	tmdbID    int
+}
+
+type contentDetails struct {
+	title string
+	date  string
+}
+
+type detailsEntry struct {
+	details contentDetails
+	expires time.Time
+}
+
+type detailsCache struct {
+	mu          sync.Mutex
+	ttl         time.Duration
+	concurrency int
+	entries     map[detailsKey]detailsEntry
+}
+
+// EnrichmentError lists the requests whose media details could not be
+// fetched by ToFriendlyAll, keyed by request ID.
+type EnrichmentError struct {
+	Failures map[int]error
+}
+
+func newDetailsCache(ttl time.Duration) *detailsCache {
+	return &detailsCache{
+		ttl:         ttl,
+		concurrency: defaultEnrichConcurrency,
+		entries:     make(map[detailsKey]detailsEntry),
+	}
+}
+
+func (c *detailsCache) get(key detailsKey) (contentDetails, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return contentDetails{}, false
+	}
+	return entry.details, true
+}
+
+func (c *detailsCache) set(key detailsKey, details contentDetails) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = detailsEntry{details: details, expires: time.Now().Add(c.ttl)}
+}
+
+// SetDetailsCacheTTL sets how long movie and tv details fetched by
+// ToFriendlyAll are cached for. A zero or negative TTL disables caching.
+func (o *Overseerr) SetDetailsCacheTTL(ttl time.Duration) {
+	o.detailsCache.mu.Lock()
+	defer o.detailsCache.mu.Unlock()
+	o.detailsCache.ttl = ttl
+	o.detailsCache.entries = make(map[detailsKey]detailsEntry)
+}
+
+// SetEnrichmentConcurrency sets the maximum number of concurrent detail
+// lookups made by ToFriendlyAll.
+func (o *Overseerr) SetEnrichmentConcurrency(limit int) {
+	if limit < 1 {
+		limit = 1
+	}
+	o.detailsCache.mu.Lock()
+	defer o.detailsCache.mu.Unlock()
+	o.detailsCache.concurrency = limit
+}
+
+// FlushDetailsCache removes all cached movie and tv details.
+func (o *Overseerr) FlushDetailsCache() {
+	o.detailsCache.mu.Lock()
+	defer o.detailsCache.mu.Unlock()
+	o.detailsCache.entries = make(map[detailsKey]detailsEntry)
+}
+
+// ToFriendlyAll converts a batch of requests to their friendly form. Details
+// are fetched once per unique media item, concurrently, and cached across
+// calls. Requests whose details cannot be fetched are given placeholder
+// content and reported in the returned *EnrichmentError, the results slice
+// is always complete unless the context is cancelled. A nil request gives a
+// nil result at the same index.
+func (o *Overseerr) ToFriendlyAll(ctx context.Context, reqs []*MediaRequest) ([]*FriendlyMediaRequest, error) {
+	o.detailsCache.mu.Lock()
+	concurrency := o.detailsCache.concurrency
+	o.detailsCache.mu.Unlock()
+
+	details := make(map[detailsKey]contentDetails)
+	fetchErrs := make(map[detailsKey]error)
+	var pending []detailsKey
+	for _, req := range reqs {
+		if req == nil || !req.Media.IsMovie() && !req.Media.IsTV() {
+			continue
+		}
+		key := detailsKey{mediaType: req.Media.MediaType, tmdbID: req.Media.TMDB}
+		if _, ok := details[key]; ok {
+			continue
+		}
+		if cached, ok := o.detailsCache.get(key); ok {
+			details[key] = cached
+			continue
+		}
+		details[key] = contentDetails{}
+		pending = append(pending, key)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for _, key := range pending {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		}
+		wg.Add(1)
+		go func(key detailsKey) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fetched, err := o.fetchContentDetails(ctx, key)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				fetchErrs[key] = err
+				return
+			}
+			details[key] = fetched
+			o.detailsCache.set(key, fetched)
+		}(key)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	friendlies := make([]*FriendlyMediaRequest, len(reqs))
+	failures := make(map[int]error)
+	for idx, req := range reqs {
+		if req == nil {
+			continue
+		}
+		friendly := req.friendlyBase()
+		key := detailsKey{mediaType: req.Media.MediaType, tmdbID: req.Media.TMDB}
+		if err, failed := fetchErrs[key]; failed {
+			failures[req.ID] = err
+			friendly.ContentTitle = fmt.Sprintf("TMDB #%d", req.Media.TMDB)
+		} else if d, ok := details[key]; ok {
+			friendly.ContentTitle = d.title
+			friendly.ContentDate = d.date
+		}
+		friendlies[idx] = friendly
+	}
+	if len(failures) > 0 {
+		return friendlies, &EnrichmentError{Failures: failures}
+	}
+	return friendlies, nil
+}
+
+func (o *Overseerr) fetchContentDetails(ctx context.Context, key detailsKey) (contentDetails, error) {
+	if key.mediaType == MediaTypeMovie {
+		movie, err := o.GetMovieDetailsContext(ctx, key.tmdbID)
+		if err != nil {
+			return contentDetails{}, err
+		}
+		return contentDetails{title: movie.Title, date: movie.ReleaseDate}, nil
+	}
+	tv, err := o.GetTVDetailsContext(ctx, key.tmdbID)
+	if err != nil {
+		return contentDetails{}, err
+	}
+	return contentDetails{title: tv.Name, date: tv.FirstAired}, nil
+}
+
+func (e *EnrichmentError) Error() string {
+	ids := make([]int, 0, len(e.Failures))
+	for id := range e.Failures {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	parts := make([]string, len(ids))
+	for idx, id := range ids {
+		parts[idx] = fmt.Sprintf("request %d: %v", id, e.Failures[id])
+	}
+	return fmt.Sprintf("failed to fetch details for %d request(s): %s", len(ids), strings.Join(parts, "; "))
+}
@@ -0,0 +1,154 @@
+package goverseerr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// detailsServer fakes the movie and tv details endpoints, failing any path
+// in fail and counting the lookups made for each path.
+type detailsServer struct {
+	mu    sync.Mutex
+	fail  map[string]bool
+	calls map[string]int
+}
+
+func newDetailsServer(fail ...string) *detailsServer {
+	s := &detailsServer{fail: make(map[string]bool), calls: make(map[string]int)}
+	for _, path := range fail {
+		s.fail[path] = true
+	}
+	return s
+}
+
+func (s *detailsServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.calls[r.URL.Path]++
+	s.mu.Unlock()
+	switch {
+	case s.fail[r.URL.Path]:
+		w.WriteHeader(http.StatusInternalServerError)
+	case r.URL.Path == "/api/v1/movie/129":
+		json.NewEncoder(w).Encode(MovieDetails{ID: 129, Title: "Spirited Away", ReleaseDate: "2001-07-20"})
+	case r.URL.Path == "/api/v1/tv/1396":
+		json.NewEncoder(w).Encode(TVDetails{ID: 1396, Name: "Breaking Bad", FirstAired: "2008-01-20"})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *detailsServer) count(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[path]
+}
+
+func testRequest(id int, mediaType MediaType, tmdbID int) *MediaRequest {
+	return &MediaRequest{ID: id, Media: MediaInfo{MediaType: mediaType, TMDB: tmdbID}}
+}
+
+func TestToFriendlyAllDeduplicates(t *testing.T) {
+	server := newDetailsServer()
+	o := newTestClient(t, server.handle)
+	reqs := []*MediaRequest{
+		testRequest(1, MediaTypeMovie, 129),
+		testRequest(2, MediaTypeTV, 1396),
+		nil,
+		testRequest(3, MediaTypeMovie, 129),
+	}
+	friendlies, err := o.ToFriendlyAll(context.Background(), reqs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(friendlies) != len(reqs) || friendlies[2] != nil {
+		t.Fatalf("friendlies = %v, want one per request with nil for the nil request", friendlies)
+	}
+	for idx, want := range map[int]string{0: "Spirited Away", 1: "Breaking Bad", 3: "Spirited Away"} {
+		if friendlies[idx].ContentTitle != want || friendlies[idx].ID != reqs[idx].ID {
+			t.Errorf("friendly %d = %+v, want %s for request %d", idx, friendlies[idx], want, reqs[idx].ID)
+		}
+	}
+	if _, err := o.ToFriendlyAll(context.Background(), reqs); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"/api/v1/movie/129", "/api/v1/tv/1396"} {
+		if n := server.count(path); n != 1 {
+			t.Errorf("%s fetched %d times, want once", path, n)
+		}
+	}
+}
+
+func TestToFriendlyAllCacheExpiry(t *testing.T) {
+	server := newDetailsServer()
+	o := newTestClient(t, server.handle)
+	reqs := []*MediaRequest{testRequest(1, MediaTypeMovie, 129)}
+	if _, err := o.ToFriendlyAll(context.Background(), reqs); err != nil {
+		t.Fatal(err)
+	}
+	o.detailsCache.mu.Lock()
+	for key, entry := range o.detailsCache.entries {
+		entry.expires = time.Now().Add(-time.Second)
+		o.detailsCache.entries[key] = entry
+	}
+	o.detailsCache.mu.Unlock()
+	if _, err := o.ToFriendlyAll(context.Background(), reqs); err != nil {
+		t.Fatal(err)
+	}
+	if n := server.count("/api/v1/movie/129"); n != 2 {
+		t.Errorf("movie fetched %d times, want it refetched once expired", n)
+	}
+
+	o.SetDetailsCacheTTL(0)
+	for i := 0; i < 2; i++ {
+		if _, err := o.ToFriendlyAll(context.Background(), reqs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := server.count("/api/v1/movie/129"); n != 4 {
+		t.Errorf("movie fetched %d times, want every call fetched without a TTL", n)
+	}
+}
+
+func TestToFriendlyAllPlaceholder(t *testing.T) {
+	server := newDetailsServer("/api/v1/tv/1396")
+	o := newTestClient(t, server.handle)
+	reqs := []*MediaRequest{
+		testRequest(1, MediaTypeMovie, 129),
+		testRequest(2, MediaTypeTV, 1396),
+	}
+	friendlies, err := o.ToFriendlyAll(context.Background(), reqs)
+	var enrichErr *EnrichmentError
+	if !errors.As(err, &enrichErr) {
+		t.Fatalf("err = %v, want an *EnrichmentError", err)
+	}
+	if len(enrichErr.Failures) != 1 || enrichErr.Failures[2] == nil {
+		t.Errorf("failures = %v, want request 2", enrichErr.Failures)
+	}
+	if friendlies[0].ContentTitle != "Spirited Away" || friendlies[1].ContentTitle != "TMDB #1396" {
+		t.Errorf("titles = %q, %q, want the movie title and a placeholder", friendlies[0].ContentTitle, friendlies[1].ContentTitle)
+	}
+	if _, err := o.ToFriendlyAll(context.Background(), reqs[1:]); err == nil {
+		t.Error("failed lookup was cached")
+	}
+}
+
+func TestToFriendlyAllCancelsLookups(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := o.ToFriendlyAll(ctx, []*MediaRequest{testRequest(1, MediaTypeMovie, 129)})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("lookup took %s after the deadline", elapsed)
+	}
+}
@@ -0,0 +1,170 @@
+package goverseerr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type operationKey struct{}
+
+// OperationInfo identifies an API call. Name is a stable, low cardinality
+// identifier such as "requests.list" or "movie.details".
+type OperationInfo struct {
+	Name   string
+	Method string
+	Path   string
+}
+
+// OperationResult describes how an API call completed. StatusCode is 0 when
+// no response was received. Duration runs until the response body has been
+// read and closed, Err includes any error reading the body.
+type OperationResult struct {
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+	ErrorClass ErrorClass
+}
+
+// ErrorClass groups call outcomes into a small set suitable for use as a
+// metric label.
+type ErrorClass string
+
+const (
+	ErrorClassNone     ErrorClass = ""
+	ErrorClassTimeout  ErrorClass = "timeout"
+	ErrorClassCanceled ErrorClass = "canceled"
+	ErrorClassNetwork  ErrorClass = "network"
+	ErrorClassAuth     ErrorClass = "auth"
+	ErrorClassNotFound ErrorClass = "not_found"
+	ErrorClassClient   ErrorClass = "client"
+	ErrorClassServer   ErrorClass = "server"
+)
+
+// Hook observes every API call made by a client. The context returned by
+// OperationStarted is used for the call and passed to OperationFinished,
+// allowing hooks such as tracers to carry state between the two.
+type Hook interface {
+	OperationStarted(ctx context.Context, op OperationInfo) context.Context
+	OperationFinished(ctx context.Context, op OperationInfo, result OperationResult)
+}
+
+type instrumentedTransport struct {
+	o    *Overseerr
+	next http.RoundTripper
+}
+
+// AddHook registers an instrumentation hook. Hooks should be added before
+// the client is shared between goroutines.
+func (o *Overseerr) AddHook(hook Hook) {
+	o.hooks = append(o.hooks, hook)
+}
+
+// OperationName returns the name of the API operation a request context
+// belongs to, or an empty string if there is none.
+func OperationName(ctx context.Context) string {
+	name, _ := ctx.Value(operationKey{}).(string)
+	return name
+}
+
+// ClassifyResult derives the error class for a call from its status code
+// and transport error.
+func ClassifyResult(statusCode int, err error) ErrorClass {
+	if err != nil {
+		var netErr net.Error
+		switch {
+		case errors.Is(err, context.Canceled):
+			return ErrorClassCanceled
+		case errors.Is(err, context.DeadlineExceeded):
+			return ErrorClassTimeout
+		case errors.As(err, &netErr) && netErr.Timeout():
+			return ErrorClassTimeout
+		default:
+			return ErrorClassNetwork
+		}
+	}
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ErrorClassAuth
+	case statusCode == http.StatusNotFound:
+		return ErrorClassNotFound
+	case statusCode >= 500:
+		return ErrorClassServer
+	case statusCode >= 400:
+		return ErrorClassClient
+	default:
+		return ErrorClassNone
+	}
+}
+
+// request starts a JSON API request tagged with the given operation name.
+func (o *Overseerr) request(operation string) *resty.Request {
+	return o.requestContext(context.Background(), operation)
+}
+
+func (o *Overseerr) requestContext(ctx context.Context, operation string) *resty.Request {
+	return o.restClient.R().
+		SetContext(context.WithValue(ctx, operationKey{}, operation)).
+		SetHeader("Accept", "application/json")
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.o.hooks) == 0 {
+		return t.next.RoundTrip(req)
+	}
+	op := OperationInfo{
+		Name:   OperationName(req.Context()),
+		Method: req.Method,
+		Path:   req.URL.Path,
+	}
+	ctx := req.Context()
+	for _, hook := range t.o.hooks {
+		ctx = hook.OperationStarted(ctx, op)
+	}
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	finish := func(err error) {
+		result := OperationResult{Duration: time.Since(start), Err: err}
+		if resp != nil {
+			result.StatusCode = resp.StatusCode
+		}
+		result.ErrorClass = ClassifyResult(result.StatusCode, err)
+		for idx := len(t.o.hooks) - 1; idx >= 0; idx-- {
+			t.o.hooks[idx].OperationFinished(ctx, op, result)
+		}
+	}
+	if err != nil || resp.Body == nil {
+		finish(err)
+		return resp, err
+	}
+	resp.Body = &instrumentedBody{ReadCloser: resp.Body, finish: finish}
+	return resp, nil
+}
+
+// instrumentedBody finishes an operation once the response body is closed.
+type instrumentedBody struct {
+	io.ReadCloser
+	finish func(err error)
+	err    error
+	once   sync.Once
+}
+
+func (b *instrumentedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && err != io.EOF {
+		b.err = err
+	}
+	return n, err
+}
+
+func (b *instrumentedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.finish(b.err) })
+	return err
+}
@@ -0,0 +1,52 @@
+package goverseerr
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingHook struct {
+	mu      sync.Mutex
+	results map[string]OperationResult
+}
+
+func (h *recordingHook) OperationStarted(ctx context.Context, op OperationInfo) context.Context {
+	return ctx
+}
+
+func (h *recordingHook) OperationFinished(ctx context.Context, op OperationInfo, result OperationResult) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.results[op.Name] = result
+}
+
+func TestOperationDurationIncludesBody(t *testing.T) {
+	const bodyDelay = 50 * time.Millisecond
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"pageInfo": {"pages": 1}, `))
+		w.(http.Flusher).Flush()
+		time.Sleep(bodyDelay)
+		w.Write([]byte(`"results": []}`))
+	})
+	hook := &recordingHook{results: make(map[string]OperationResult)}
+	o.AddHook(hook)
+
+	if _, _, err := o.GetRequests(0, 10, RequestFileterAll, RequestSortAdded); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := o.GetRequestsByUser(0, 10, 1, RequestFileterAll, RequestSortAdded); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"requests.list", "requests.by_user"} {
+		result, ok := hook.results[name]
+		if !ok {
+			t.Fatalf("no result recorded for %s: %v", name, hook.results)
+		}
+		if result.Duration < bodyDelay || result.StatusCode != http.StatusOK || result.Err != nil {
+			t.Errorf("%s result = %+v, want a successful call lasting at least %s", name, result, bodyDelay)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package goverseerr
+
+import (
+	"fmt"
+	"time"
+)
+
+type Issue struct {
+	ID             int            `json:"id"`
+	Type           IssueType      `json:"issueType"`
+	Status         IssueStatus    `json:"status"`
+	ProblemSeason  int            `json:"problemSeason"`
+	ProblemEpisode int            `json:"problemEpisode"`
+	Media          MediaInfo      `json:"media"`
+	Creator        User           `json:"createdBy"`
+	LastModifier   User           `json:"modifiedBy"`
+	Comments       []IssueComment `json:"comments"`
+	Created        time.Time      `json:"createdAt"`
+	Modified       time.Time      `json:"updatedAt"`
+}
+
+type IssueComment struct {
+	ID       int       `json:"id"`
+	User     User      `json:"user"`
+	Message  string    `json:"message"`
+	Created  time.Time `json:"createdAt"`
+	Modified time.Time `json:"updatedAt"`
+}
+
+type IssueResponse struct {
+	PageInfo Page     `json:"pageInfo"`
+	Results  []*Issue `json:"results"`
+}
+
+type IssueType int
+type IssueStatus int
+
+const (
+	IssueTypeVideo     IssueType = 1
+	IssueTypeAudio     IssueType = 2
+	IssueTypeSubtitles IssueType = 3
+	IssueTypeOther     IssueType = 4
+)
+
+const (
+	IssueStatusOpen     IssueStatus = 1
+	IssueStatusResolved IssueStatus = 2
+)
+
+func (o *Overseerr) GetIssues(pageNumber, pageSize int) ([]*Issue, *Page, error) {
+	var issues IssueResponse
+	resp, err := o.request("issues.list").SetQueryParams(map[string]string{
+		"take": fmt.Sprintf("%d", pageSize),
+		"skip": fmt.Sprintf("%d", pageSize*pageNumber),
+	}).SetResult(&issues).Get("/issue")
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, nil, fmt.Errorf("received non-200 status code (%d)", resp.StatusCode())
+	}
+	return issues.Results, &issues.PageInfo, nil
+}
+
+func (o *Overseerr) GetIssue(issueID int) (*Issue, error) {
+	var issue Issue
+	resp, err := o.request("issues.get").SetPathParam("issueID", fmt.Sprintf("%d", issueID)).
+		SetResult(&issue).Get("/issue/{issueID}")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("received non-200 status code (%d)", resp.StatusCode())
+	}
+	return &issue, nil
+}
+
+func (t IssueType) ToString() string {
+	switch t {
+	case IssueTypeVideo:
+		return "Video"
+	case IssueTypeAudio:
+		return "Audio"
+	case IssueTypeSubtitles:
+		return "Subtitles"
+	case IssueTypeOther:
+		return "Other"
+	default:
+		return "Unknown"
+	}
+}
+
+func (t IssueType) ToEmoji() string {
+	switch t {
+	case IssueTypeVideo:
+		return "🎞"
+	case IssueTypeAudio:
+		return "🔈"
+	case IssueTypeSubtitles:
+		return "💬"
+	default:
+		return "❓"
+	}
+}
+
+func (s IssueStatus) ToString() string {
+	switch s {
+	case IssueStatusOpen:
+		return "Open"
+	case IssueStatusResolved:
+		return "Resolved"
+	default:
+		return "Unknown"
+	}
+}
+
+func (s IssueStatus) ToEmoji() string {
+	switch s {
+	case IssueStatusOpen:
+		return "⚠️"
+	case IssueStatusResolved:
+		return "✅"
+	default:
+		return "❓"
+	}
+}
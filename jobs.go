@@ -1,29 +1,63 @@
 package goverseerr
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
 
 type Job struct {
-	ID          string
-	Name        string
-	Type        JobType
-	NextRunTime time.Time
-	Running     bool
+	ID           JobID       `json:"id"`
+	Name         string      `json:"name"`
+	Type         JobType     `json:"type"`
+	Interval     JobInterval `json:"interval"`
+	CronSchedule string      `json:"cronSchedule"`
+	NextRunTime  time.Time   `json:"nextExecutionTime"`
+	Running      bool        `json:"running"`
 }
 
+// JobID identifies a job. It is an alias of string so IDs held in plain
+// strings can still be passed to RunJob and CancelJob.
+type JobID = string
+
 type JobType string
+type JobInterval string
+
+const (
+	JobIDPlexRecentlyAddedScan JobID = "plex-recently-added-scan"
+	JobIDPlexFullScan          JobID = "plex-full-scan"
+	JobIDRadarrScan            JobID = "radarr-scan"
+	JobIDSonarrScan            JobID = "sonarr-scan"
+	JobIDDownloadSync          JobID = "download-sync"
+	JobIDDownloadSyncReset     JobID = "download-sync-reset"
+	JobIDAvailabilitySync      JobID = "availability-sync"
+	JobIDImageCacheCleanup     JobID = "image-cache-cleanup"
+)
 
 const (
 	JobTypeProcess JobType = "process"
 	JobTypeCommand JobType = "command"
 )
 
+// Older Overseerr releases report short/long, newer releases report the
+// unit the schedule is editable in.
+const (
+	JobIntervalShort   JobInterval = "short"
+	JobIntervalLong    JobInterval = "long"
+	JobIntervalSeconds JobInterval = "seconds"
+	JobIntervalMinutes JobInterval = "minutes"
+	JobIntervalHours   JobInterval = "hours"
+	JobIntervalFixed   JobInterval = "fixed"
+)
+
 func (o *Overseerr) GetJobs() ([]*Job, error) {
+	return o.GetJobsContext(context.Background())
+}
+
+// GetJobsContext is GetJobs with a context for the request.
+func (o *Overseerr) GetJobsContext(ctx context.Context) ([]*Job, error) {
 	var jobs []*Job
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.requestContext(ctx, "jobs.list").
 		SetResult(&jobs).Get("/settings/jobs")
 	if err != nil {
 		return nil, err
@@ -34,10 +68,9 @@ func (o *Overseerr) GetJobs() ([]*Job, error) {
 	return jobs, nil
 }
 
-func (o *Overseerr) RunJob(jobID string) (*Job, error) {
+func (o *Overseerr) RunJob(jobID JobID) (*Job, error) {
 	var job *Job
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("jobID", jobID).
+	resp, err := o.request("jobs.run").SetPathParam("jobID", string(jobID)).
 		SetResult(&job).Post("/settings/jobs/{jobID}/run")
 	if err != nil {
 		return nil, err
@@ -48,10 +81,9 @@ func (o *Overseerr) RunJob(jobID string) (*Job, error) {
 	return job, nil
 }
 
-func (o *Overseerr) CancelJob(jobID string) (*Job, error) {
+func (o *Overseerr) CancelJob(jobID JobID) (*Job, error) {
 	var job *Job
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("jobID", jobID).
+	resp, err := o.request("jobs.cancel").SetPathParam("jobID", string(jobID)).
 		SetResult(&job).Post("/settings/jobs/{jobID}/cancel")
 	if err != nil {
 		return nil, err
@@ -61,3 +93,95 @@ func (o *Overseerr) CancelJob(jobID string) (*Job, error) {
 	}
 	return job, nil
 }
+
+// UpdateJobSchedule sets the cron schedule a job runs on. Overseerr uses six
+// field cron expressions, the first field being seconds. The schedule is
+// validated before being sent.
+func (o *Overseerr) UpdateJobSchedule(jobID JobID, cron string) (*Job, error) {
+	if _, err := ParseCronSchedule(cron); err != nil {
+		return nil, err
+	}
+	var job *Job
+	resp, err := o.request("jobs.schedule").SetPathParam("jobID", string(jobID)).
+		SetBody(map[string]string{
+			"schedule": cron,
+		}).SetResult(&job).Post("/settings/jobs/{jobID}/schedule")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("received non-200 status code (%d)", resp.StatusCode())
+	}
+	return job, nil
+}
+
+type JobRunSummary struct {
+	JobID     JobID
+	Started   time.Time
+	Finished  time.Time
+	Duration  time.Duration
+	Cancelled bool
+}
+
+// RunJobAndWait runs a job and polls until it is no longer running. As the
+// first poll can land before the job has started, the job only counts as
+// finished once it has been seen running or its next run time has moved on;
+// a job that finishes before either is seen keeps the call waiting, so ctx
+// should carry a deadline. If the context is cancelled first the job is
+// cancelled and the context's error is returned alongside the summary.
+func (o *Overseerr) RunJobAndWait(ctx context.Context, jobID JobID) (*JobRunSummary, error) {
+	summary := JobRunSummary{JobID: jobID, Started: time.Now()}
+	finish := func() *JobRunSummary {
+		summary.Finished = time.Now()
+		summary.Duration = summary.Finished.Sub(summary.Started)
+		return &summary
+	}
+	cancel := func(err error) (*JobRunSummary, error) {
+		summary.Cancelled = true
+		if _, cancelErr := o.CancelJob(jobID); cancelErr != nil {
+			return finish(), fmt.Errorf("%w (failed to cancel job: %v)", err, cancelErr)
+		}
+		return finish(), err
+	}
+	job, err := o.RunJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+	var started bool
+	var nextRun time.Time
+	if job != nil {
+		started, nextRun = job.Running, job.NextRunTime
+	}
+	wait := newBackoff()
+	for {
+		if err := wait.wait(ctx); err != nil {
+			return cancel(err)
+		}
+		jobs, err := o.GetJobsContext(ctx)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return cancel(ctxErr)
+			}
+			return finish(), err
+		}
+		found := false
+		for _, job := range jobs {
+			if job.ID != jobID {
+				continue
+			}
+			found = true
+			switch {
+			case job.Running:
+				started = true
+			case started || !job.NextRunTime.Equal(nextRun):
+				return finish(), nil
+			default:
+				// keep polling quickly until the job is seen starting
+				wait = newBackoff()
+			}
+		}
+		if !found {
+			return finish(), fmt.Errorf("job %s not found", jobID)
+		}
+	}
+}
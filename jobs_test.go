@@ -0,0 +1,117 @@
+package goverseerr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// jobServer fakes the job endpoints. Run answers with the run state, each
+// poll of the job list answers with the next state in polls, the last one
+// repeating, and cancels are counted.
+type jobServer struct {
+	mu      sync.Mutex
+	run     Job
+	polls   []Job
+	calls   int
+	cancels int
+}
+
+func (s *jobServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.URL.Path {
+	case "/api/v1/settings/jobs/download-sync/run":
+		json.NewEncoder(w).Encode(s.run)
+	case "/api/v1/settings/jobs/download-sync/cancel":
+		s.cancels++
+		json.NewEncoder(w).Encode(s.run)
+	case "/api/v1/settings/jobs":
+		job := s.polls[min(s.calls, len(s.polls)-1)]
+		s.calls++
+		json.NewEncoder(w).Encode([]Job{{ID: JobIDPlexFullScan}, job})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestRunJobAndWait(t *testing.T) {
+	next := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		run   Job
+		polls []Job
+		calls int
+	}{
+		{
+			name:  "running when run",
+			run:   Job{ID: JobIDDownloadSync, Running: true, NextRunTime: next},
+			polls: []Job{{ID: JobIDDownloadSync, NextRunTime: next}},
+			calls: 1,
+		},
+		{
+			name: "seen running later",
+			run:  Job{ID: JobIDDownloadSync, NextRunTime: next},
+			polls: []Job{
+				{ID: JobIDDownloadSync, NextRunTime: next},
+				{ID: JobIDDownloadSync, Running: true, NextRunTime: next},
+				{ID: JobIDDownloadSync, NextRunTime: next},
+			},
+			calls: 3,
+		},
+		{
+			name: "next run moved on",
+			run:  Job{ID: JobIDDownloadSync, NextRunTime: next},
+			polls: []Job{
+				{ID: JobIDDownloadSync, NextRunTime: next},
+				{ID: JobIDDownloadSync, NextRunTime: next.Add(time.Minute)},
+			},
+			calls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &jobServer{run: tt.run, polls: tt.polls}
+			o := newTestClient(t, server.handle)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			summary, err := o.RunJobAndWait(ctx, JobIDDownloadSync)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if summary.Cancelled || summary.JobID != JobIDDownloadSync || summary.Duration <= 0 {
+				t.Errorf("summary = %+v, want a finished run", summary)
+			}
+			if server.calls != tt.calls || server.cancels != 0 {
+				t.Errorf("polled %d times and cancelled %d times, want %d polls", server.calls, server.cancels, tt.calls)
+			}
+		})
+	}
+}
+
+func TestRunJobAndWaitCancelled(t *testing.T) {
+	running := Job{ID: JobIDDownloadSync, Running: true}
+	server := &jobServer{run: running, polls: []Job{running}}
+	o := newTestClient(t, server.handle)
+	ctx, cancel := context.WithTimeout(context.Background(), 700*time.Millisecond)
+	defer cancel()
+	summary, err := o.RunJobAndWait(ctx, JobIDDownloadSync)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if !summary.Cancelled || server.cancels != 1 {
+		t.Errorf("summary = %+v with %d cancels, want the job cancelled once", summary, server.cancels)
+	}
+}
+
+func TestRunJobAndWaitMissing(t *testing.T) {
+	server := &jobServer{run: Job{ID: JobIDDownloadSync, Running: true}, polls: []Job{{ID: JobIDSonarrScan}}}
+	o := newTestClient(t, server.handle)
+	if _, err := o.RunJobAndWait(context.Background(), JobIDDownloadSync); err == nil {
+		t.Fatal("RunJobAndWait() succeeded for a job missing from the list")
+	}
+}
@@ -0,0 +1,301 @@
+package goverseerr
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type LogExportFormat string
+
+const (
+	LogExportJSONLines LogExportFormat = "jsonl"
+	LogExportCSV       LogExportFormat = "csv"
+	LogExportLogfmt    LogExportFormat = "logfmt"
+)
+
+const exportPageSize int = 500
+
+// LogBucket counts the entries for a label and level within a time window.
+type LogBucket struct {
+	Start time.Time
+	Label string
+	Level LogLevel
+	Count int
+}
+
+// RecurringMessage is a group of entries whose messages are identical once
+// IDs, numbers and addresses have been normalised.
+type RecurringMessage struct {
+	Pattern string
+	Label   string
+	Level   LogLevel
+	Count   int
+	First   time.Time
+	Last    time.Time
+	Example string
+}
+
+type LogReport struct {
+	From      time.Time
+	To        time.Time
+	Total     int
+	ByLabel   map[string]map[LogLevel]int
+	Buckets   []LogBucket
+	TopErrors []RecurringMessage
+}
+
+var logNormalisers = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), "<uuid>"},
+	{regexp.MustCompile(`\b\d{1,3}(\.\d{1,3}){3}(:\d+)?\b`), "<ip>"},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8,}\b`), "<hex>"},
+	{regexp.MustCompile(`\d+(\.\d+)?`), "<n>"},
+}
+
+// ExportLogs pages through every log entry matching the query and writes
+// them to w in the given format, a page at a time, returning the number of
+// entries written. If a page cannot be fetched or written the entries
+// already written are counted alongside the error. The query's Take and Skip
+// are ignored.
+func (o *Overseerr) ExportLogs(w io.Writer, format LogExportFormat, query LogQuery) (int, error) {
+	lw, err := newLogWriter(w, format)
+	if err != nil {
+		return 0, err
+	}
+	written := 0
+	query.Take = exportPageSize
+	for page := 0; ; page++ {
+		query.Skip = page * exportPageSize
+		entries, pageInfo, err := o.GetLogs(query)
+		if err != nil {
+			return written, err
+		}
+		if err := lw.write(entries); err != nil {
+			return written, err
+		}
+		written += len(entries)
+		if len(entries) == 0 || page+1 >= pageInfo.Pages {
+			return written, nil
+		}
+	}
+}
+
+// WriteLogs writes log entries to w in the given format.
+func WriteLogs(w io.Writer, format LogExportFormat, entries []*LogMessage) error {
+	lw, err := newLogWriter(w, format)
+	if err != nil {
+		return err
+	}
+	return lw.write(entries)
+}
+
+// logWriter writes log entries in one format across any number of batches,
+// flushing each batch through to the underlying writer.
+type logWriter struct {
+	format LogExportFormat
+	w      *bufio.Writer
+	json   *json.Encoder
+	csv    *csv.Writer
+}
+
+func newLogWriter(w io.Writer, format LogExportFormat) (*logWriter, error) {
+	lw := &logWriter{format: format, w: bufio.NewWriter(w)}
+	switch format {
+	case LogExportJSONLines:
+		lw.json = json.NewEncoder(lw.w)
+	case LogExportCSV:
+		lw.csv = csv.NewWriter(lw.w)
+		if err := lw.csv.Write([]string{"timestamp", "level", "label", "message", "data"}); err != nil {
+			return nil, err
+		}
+	case LogExportLogfmt:
+	default:
+		return nil, fmt.Errorf("unsupported log export format: %s", format)
+	}
+	return lw, nil
+}
+
+func (lw *logWriter) write(entries []*LogMessage) error {
+	for _, entry := range entries {
+		if err := lw.writeEntry(entry); err != nil {
+			return err
+		}
+	}
+	if lw.csv != nil {
+		lw.csv.Flush()
+		if err := lw.csv.Error(); err != nil {
+			return err
+		}
+	}
+	return lw.w.Flush()
+}
+
+func (lw *logWriter) writeEntry(entry *LogMessage) error {
+	switch lw.format {
+	case LogExportJSONLines:
+		return lw.json.Encode(entry)
+	case LogExportCSV:
+		data := ""
+		if len(entry.Data) > 0 {
+			encoded, err := json.Marshal(entry.Data)
+			if err != nil {
+				return err
+			}
+			data = string(encoded)
+		}
+		return lw.csv.Write([]string{entry.Timestamp.Format(time.RFC3339Nano), string(entry.Level), entry.Label, entry.Message, data})
+	default:
+		return writeLogfmt(lw.w, entry)
+	}
+}
+
+func writeLogfmt(w *bufio.Writer, entry *LogMessage) error {
+	pairs := []string{
+		"time=" + entry.Timestamp.Format(time.RFC3339Nano),
+		"level=" + logfmtValue(string(entry.Level)),
+		"label=" + logfmtValue(entry.Label),
+		"msg=" + logfmtValue(entry.Message),
+	}
+	keys := make([]string, 0, len(entry.Data))
+	for key := range entry.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		var value string
+		switch v := entry.Data[key].(type) {
+		case string:
+			value = v
+		default:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			value = string(encoded)
+		}
+		pairs = append(pairs, logfmtKey(key)+"="+logfmtValue(value))
+	}
+	_, err := w.WriteString(strings.Join(pairs, " ") + "\n")
+	return err
+}
+
+// logfmtKey makes key usable unquoted, as logfmt keys cannot be quoted:
+// whitespace, control characters, '=' and '"' become '_'.
+func logfmtKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || unicode.IsControl(r) || r == '=' || r == '"' {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+func logfmtValue(value string) string {
+	if value == "" || strings.ContainsAny(value, " =\"\t\n") {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// NormaliseLogMessage replaces the variable parts of a message, such as IDs,
+// numbers and addresses, with placeholders so similar messages group.
+func NormaliseLogMessage(message string) string {
+	for _, n := range logNormalisers {
+		message = n.pattern.ReplaceAllString(message, n.replacement)
+	}
+	return message
+}
+
+// AggregateLogs groups entries by label and level into windows of the given
+// size and returns the topN most frequent warning and error messages.
+func AggregateLogs(entries []*LogMessage, window time.Duration, topN int) *LogReport {
+	report := LogReport{ByLabel: make(map[string]map[LogLevel]int)}
+	type bucketKey struct {
+		start time.Time
+		label string
+		level LogLevel
+	}
+	type messageKey struct {
+		pattern string
+		label   string
+		level   LogLevel
+	}
+	buckets := make(map[bucketKey]int)
+	messages := make(map[messageKey]*RecurringMessage)
+	for _, entry := range entries {
+		report.Total++
+		if report.From.IsZero() || entry.Timestamp.Before(report.From) {
+			report.From = entry.Timestamp
+		}
+		if entry.Timestamp.After(report.To) {
+			report.To = entry.Timestamp
+		}
+		if report.ByLabel[entry.Label] == nil {
+			report.ByLabel[entry.Label] = make(map[LogLevel]int)
+		}
+		report.ByLabel[entry.Label][entry.Level]++
+		if window > 0 {
+			buckets[bucketKey{start: entry.Timestamp.Truncate(window), label: entry.Label, level: entry.Level}]++
+		}
+		if !entry.Level.AtLeast(LogLevelWarn) {
+			continue
+		}
+		key := messageKey{pattern: NormaliseLogMessage(entry.Message), label: entry.Label, level: entry.Level}
+		msg, ok := messages[key]
+		if !ok {
+			msg = &RecurringMessage{Pattern: key.pattern, Label: key.label, Level: key.level, First: entry.Timestamp, Last: entry.Timestamp, Example: entry.Message}
+			messages[key] = msg
+		}
+		msg.Count++
+		if entry.Timestamp.Before(msg.First) {
+			msg.First = entry.Timestamp
+		}
+		if entry.Timestamp.After(msg.Last) {
+			msg.Last = entry.Timestamp
+		}
+	}
+	for key, count := range buckets {
+		report.Buckets = append(report.Buckets, LogBucket{Start: key.start, Label: key.label, Level: key.level, Count: count})
+	}
+	sort.Slice(report.Buckets, func(i, j int) bool {
+		a, b := report.Buckets[i], report.Buckets[j]
+		if !a.Start.Equal(b.Start) {
+			return a.Start.Before(b.Start)
+		}
+		if a.Label != b.Label {
+			return a.Label < b.Label
+		}
+		return a.Level.Severity() > b.Level.Severity()
+	})
+	for _, msg := range messages {
+		report.TopErrors = append(report.TopErrors, *msg)
+	}
+	sort.Slice(report.TopErrors, func(i, j int) bool {
+		a, b := report.TopErrors[i], report.TopErrors[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Level != b.Level {
+			return a.Level.Severity() > b.Level.Severity()
+		}
+		return a.Pattern < b.Pattern
+	})
+	if topN > 0 && len(report.TopErrors) > topN {
+		report.TopErrors = report.TopErrors[:topN]
+	}
+	return &report
+}
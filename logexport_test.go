@@ -0,0 +1,143 @@
+package goverseerr
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testLogTime = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func TestExportLogsStreamsPages(t *testing.T) {
+	tests := []struct {
+		name    string
+		failAt  int
+		written int
+		lines   int
+	}{
+		{name: "complete", written: 6, lines: 7},
+		{name: "failed page", failAt: 2, written: 4, lines: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			var seen []int
+			o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+				page := skip / exportPageSize
+				// what had been written before this page was asked for
+				seen = append(seen, strings.Count(out.String(), "\n"))
+				if tt.failAt > 0 && page == tt.failAt {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				json.NewEncoder(w).Encode(LogResponse{
+					PageInfo: Page{Page: page + 1, Pages: 3},
+					Entries: []*LogMessage{
+						{Label: "Jobs", Level: LogLevelInfo, Message: "page " + strconv.Itoa(page), Timestamp: testLogTime},
+						{Label: "Jobs", Level: LogLevelWarn, Message: "slow", Timestamp: testLogTime},
+					},
+				})
+			})
+			written, err := o.ExportLogs(&out, LogExportCSV, LogQuery{Take: 5, Skip: 10})
+			if (err != nil) != (tt.failAt > 0) {
+				t.Fatalf("err = %v", err)
+			}
+			if written != tt.written || strings.Count(out.String(), "\n") != tt.lines {
+				t.Errorf("wrote %d entries as:\n%s\nwant %d entries on %d lines", written, out.String(), tt.written, tt.lines)
+			}
+			if want := []int{0, 3, 5}[:len(seen)]; !reflect.DeepEqual(seen, want) {
+				t.Errorf("lines written before each page = %v, want %v", seen, want)
+			}
+		})
+	}
+}
+
+func TestWriteLogsLogfmtKeys(t *testing.T) {
+	var out bytes.Buffer
+	entry := &LogMessage{
+		Label:     "Plex Scan",
+		Level:     LogLevelError,
+		Message:   `failed "badly"`,
+		Timestamp: testLogTime,
+		Data:      map[string]any{"a key": "b", "x=y": 2, "": true, "tab\tkey": "v"},
+	}
+	if err := WriteLogs(&out, LogExportLogfmt, []*LogMessage{entry}); err != nil {
+		t.Fatal(err)
+	}
+	want := `time=2026-03-01T12:00:00Z level=error label="Plex Scan" msg="failed \"badly\"" _=true a_key=b tab_key=v x_y=2` + "\n"
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestNormaliseLogMessage(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{message: "Request 42 approved", want: "Request <n> approved"},
+		{message: "Took 1.5s", want: "Took <n>s"},
+		{message: "Session 123e4567-e89b-12d3-a456-426614174000 expired", want: "Session <uuid> expired"},
+		{message: "Connection to 10.0.0.12:32400 refused", want: "Connection to <ip> refused"},
+		{message: "Hash deadbeefcafe mismatch", want: "Hash <hex> mismatch"},
+		{message: "No variable parts", want: "No variable parts"},
+	}
+	for _, tt := range tests {
+		if got := NormaliseLogMessage(tt.message); got != tt.want {
+			t.Errorf("NormaliseLogMessage(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestAggregateLogs(t *testing.T) {
+	at := func(minutes int) time.Time { return testLogTime.Add(time.Duration(minutes) * time.Minute) }
+	entries := []*LogMessage{
+		{Label: "Plex", Level: LogLevelError, Message: "Failed to reach 10.0.0.1:32400", Timestamp: at(3)},
+		{Label: "Plex", Level: LogLevelError, Message: "Failed to reach 10.0.0.2:32400", Timestamp: at(1)},
+		{Label: "Plex", Level: LogLevelInfo, Message: "Scan started", Timestamp: at(2)},
+		{Label: "Jobs", Level: LogLevelWarn, Message: "Job 7 slow", Timestamp: at(12)},
+		{Label: "Jobs", Level: LogLevelError, Message: "Job 8 failed", Timestamp: at(14)},
+		{Label: "Jobs", Level: LogLevelDebug, Message: "tick", Timestamp: at(0)},
+	}
+	report := AggregateLogs(entries, 10*time.Minute, 2)
+	if report.Total != 6 || !report.From.Equal(at(0)) || !report.To.Equal(at(14)) {
+		t.Errorf("report covers %d entries from %s to %s", report.Total, report.From, report.To)
+	}
+	wantLabels := map[string]map[LogLevel]int{
+		"Plex": {LogLevelError: 2, LogLevelInfo: 1},
+		"Jobs": {LogLevelWarn: 1, LogLevelError: 1, LogLevelDebug: 1},
+	}
+	if !reflect.DeepEqual(report.ByLabel, wantLabels) {
+		t.Errorf("by label = %v, want %v", report.ByLabel, wantLabels)
+	}
+	wantBuckets := []LogBucket{
+		{Start: at(0), Label: "Jobs", Level: LogLevelDebug, Count: 1},
+		{Start: at(0), Label: "Plex", Level: LogLevelError, Count: 2},
+		{Start: at(0), Label: "Plex", Level: LogLevelInfo, Count: 1},
+		{Start: at(10), Label: "Jobs", Level: LogLevelError, Count: 1},
+		{Start: at(10), Label: "Jobs", Level: LogLevelWarn, Count: 1},
+	}
+	if !reflect.DeepEqual(report.Buckets, wantBuckets) {
+		t.Errorf("buckets = %+v, want %+v", report.Buckets, wantBuckets)
+	}
+	if len(report.TopErrors) != 2 {
+		t.Fatalf("top errors = %+v, want 2", report.TopErrors)
+	}
+	top := report.TopErrors[0]
+	if top.Pattern != "Failed to reach <ip>" || top.Count != 2 || !top.First.Equal(at(1)) || !top.Last.Equal(at(3)) || top.Example != entries[0].Message {
+		t.Errorf("top error = %+v, want the grouped plex failures", top)
+	}
+	if next := report.TopErrors[1]; next.Pattern != "Job <n> failed" {
+		t.Errorf("second error = %+v, want the job failure ranked above the warning", next)
+	}
+
+	if report := AggregateLogs(entries, 0, 0); len(report.Buckets) != 0 || len(report.TopErrors) != 3 {
+		t.Errorf("without a window or limit: %d buckets, %d top errors, want 0 and 3", len(report.Buckets), len(report.TopErrors))
+	}
+}
@@ -13,10 +13,21 @@ type LogResponse struct {
 }
 
 type LogMessage struct {
-	Label     string    `json:"label"`
-	Level     LogLevel  `json:"level"`
-	Message   string    `json:"message"`
-	Timestamp time.Time `json:"timestamp"`
+	Label     string         `json:"label"`
+	Level     LogLevel       `json:"level"`
+	Message   string         `json:"message"`
+	Timestamp time.Time      `json:"timestamp"`
+	Data      map[string]any `json:"data,omitempty"`
+}
+
+// LogQuery selects a page of logs. A zero Take leaves the page size to
+// Overseerr's default. Filter returns entries at the given level and above,
+// Search matches against the message and label.
+type LogQuery struct {
+	Take   int
+	Skip   int
+	Filter LogLevel
+	Search string
 }
 
 type LogLevel string
@@ -43,20 +54,49 @@ func StringToLogLevel(level string) (LogLevel, error) {
 	}
 }
 
-func (o *Overseerr) GetLogs(take, skip int, filter LogLevel) ([]*LogMessage, error) {
+// Severity orders log levels from debug (0) to error (3). Unknown levels
+// have a severity of -1.
+func (l LogLevel) Severity() int {
+	switch l {
+	case LogLevelDebug:
+		return 0
+	case LogLevelInfo:
+		return 1
+	case LogLevelWarn:
+		return 2
+	case LogLevelError:
+		return 3
+	default:
+		return -1
+	}
+}
+
+// AtLeast reports whether the level is as or more severe than min.
+func (l LogLevel) AtLeast(min LogLevel) bool {
+	return l.Severity() >= min.Severity()
+}
+
+func (o *Overseerr) GetLogs(query LogQuery) ([]*LogMessage, *Page, error) {
 	var logs LogResponse
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetQueryParams(map[string]string{
-		"take":   fmt.Sprintf("%d", take),
-		"skip":   fmt.Sprintf("%d", skip),
-		"filter": string(filter),
-	}).
+	params := map[string]string{
+		"skip": fmt.Sprintf("%d", query.Skip),
+	}
+	if query.Take > 0 {
+		params["take"] = fmt.Sprintf("%d", query.Take)
+	}
+	if query.Filter != "" {
+		params["filter"] = string(query.Filter)
+	}
+	if query.Search != "" {
+		params["search"] = query.Search
+	}
+	resp, err := o.request("logs.list").SetQueryParams(params).
 		SetResult(&logs).Get("/settings/logs")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if resp.StatusCode() != 200 {
-		return nil, fmt.Errorf("received non-200 status code (%d)", resp.StatusCode())
+		return nil, nil, fmt.Errorf("received non-200 status code (%d)", resp.StatusCode())
 	}
-	return logs.Entries, nil
+	return logs.Entries, &logs.PageInfo, nil
 }
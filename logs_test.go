@@ -0,0 +1,37 @@
+package goverseerr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetLogsTake(t *testing.T) {
+	var query map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/settings/logs" {
+			query = r.URL.Query()
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": 1, "results": []any{}})
+	}))
+	defer server.Close()
+	o, err := NewKeyAuth(server.URL, nil, "en", "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := o.GetLogs(LogQuery{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := query["take"]; ok {
+		t.Errorf("zero Take sent take=%v", query["take"])
+	}
+	if _, _, err := o.GetLogs(LogQuery{Take: 50}); err != nil {
+		t.Fatal(err)
+	}
+	if got := query["take"]; len(got) != 1 || got[0] != "50" {
+		t.Errorf("take = %v, want 50", got)
+	}
+}
@@ -0,0 +1,153 @@
+package goverseerr
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+const (
+	tailPageSize int           = 100
+	tailMaxPages int           = 10
+	tailSeenSpan time.Duration = time.Minute
+)
+
+type logKey struct {
+	timestamp int64
+	label     string
+	message   string
+}
+
+// TailLogs polls for logs at the given level and above, emitting each new
+// entry once and in chronological order. Only entries logged after tailing
+// starts are emitted. Polls that fail are retried on the next interval, which
+// defaults to 30 seconds if not positive. The channel is closed once the
+// context is cancelled.
+func (o *Overseerr) TailLogs(ctx context.Context, level LogLevel, interval time.Duration) <-chan LogMessage {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	out := make(chan LogMessage)
+	go func() {
+		defer close(out)
+		seen := make(map[logKey]time.Time)
+		var cursor time.Time
+		seeded := false
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			entries, err := o.newLogs(level, seen, cursor)
+			if err == nil {
+				for _, entry := range entries {
+					if entry.Timestamp.After(cursor) {
+						cursor = entry.Timestamp
+					}
+					if !seeded {
+						continue
+					}
+					select {
+					case out <- *entry:
+					case <-ctx.Done():
+						return
+					}
+				}
+				seeded = true
+				for key, ts := range seen {
+					if ts.Before(cursor.Add(-tailSeenSpan)) {
+						delete(seen, key)
+					}
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return out
+}
+
+// TailLogsToHandler tails logs as TailLogs does and passes each entry to the
+// handler as a slog record, so Overseerr's logs can be merged into another
+// log pipeline. It blocks until the context is cancelled or the handler
+// returns an error.
+func (o *Overseerr) TailLogsToHandler(ctx context.Context, level LogLevel, interval time.Duration, handler slog.Handler) error {
+	for entry := range o.TailLogs(ctx, level, interval) {
+		record := entry.Record()
+		if !handler.Enabled(ctx, record.Level) {
+			continue
+		}
+		if err := handler.Handle(ctx, record); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// Record converts the log entry to a slog record, with the label and any
+// structured data as attributes.
+func (m LogMessage) Record() slog.Record {
+	record := slog.NewRecord(m.Timestamp, m.Level.SlogLevel(), m.Message, 0)
+	record.AddAttrs(slog.String("label", m.Label))
+	keys := make([]string, 0, len(m.Data))
+	for key := range m.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		record.AddAttrs(slog.Any(key, m.Data[key]))
+	}
+	return record
+}
+
+// SlogLevel maps the log level to its slog equivalent.
+func (l LogLevel) SlogLevel() slog.Level {
+	switch l {
+	case LogLevelDebug:
+		return slog.LevelDebug
+	case LogLevelWarn:
+		return slog.LevelWarn
+	case LogLevelError:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// newLogs pages back from the newest logs until it reaches entries that have
+// already been seen, returning the unseen entries oldest first.
+func (o *Overseerr) newLogs(level LogLevel, seen map[logKey]time.Time, cursor time.Time) ([]*LogMessage, error) {
+	var fresh []*LogMessage
+	for page := 0; page < tailMaxPages; page++ {
+		entries, pageInfo, err := o.GetLogs(LogQuery{
+			Take:   tailPageSize,
+			Skip:   page * tailPageSize,
+			Filter: level,
+		})
+		if err != nil {
+			return nil, err
+		}
+		reachedSeen := false
+		for _, entry := range entries {
+			key := logKey{timestamp: entry.Timestamp.UnixNano(), label: entry.Label, message: entry.Message}
+			if _, ok := seen[key]; ok || entry.Timestamp.Before(cursor.Add(-tailSeenSpan)) {
+				reachedSeen = true
+				continue
+			}
+			seen[key] = entry.Timestamp
+			fresh = append(fresh, entry)
+		}
+		if reachedSeen || cursor.IsZero() || len(entries) == 0 || page+1 >= pageInfo.Pages {
+			break
+		}
+	}
+	for i, j := 0, len(fresh)-1; i < j; i, j = i+1, j-1 {
+		fresh[i], fresh[j] = fresh[j], fresh[i]
+	}
+	sort.SliceStable(fresh, func(i, j int) bool {
+		return fresh[i].Timestamp.Before(fresh[j].Timestamp)
+	})
+	return fresh, nil
+}
@@ -1,6 +1,7 @@
 package goverseerr
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -233,3 +234,43 @@ func (o *Overseerr) GetTV(tvID int) (*TVDetails, []GenericSearchResult, []Generi
 	}
 	return details, recommendations.Results, similar.Results, ratings, nil
 }
+
+type MediaFilter string
+type MediaSort string
+
+type MediaResponse struct {
+	PageInfo Page         `json:"pageInfo"`
+	Results  []*MediaInfo `json:"results"`
+}
+
+const (
+	MediaFilterAll          MediaFilter = "all"
+	MediaFilterAvailable    MediaFilter = "available"
+	MediaFilterPartial      MediaFilter = "partial"
+	MediaFilterAllAvailable MediaFilter = "allavailable"
+	MediaFilterProcessing   MediaFilter = "processing"
+	MediaFilterPending      MediaFilter = "pending"
+)
+
+const (
+	MediaSortAdded      MediaSort = "added"
+	MediaSortModified   MediaSort = "modified"
+	MediaSortMediaAdded MediaSort = "mediaAdded"
+)
+
+func (o *Overseerr) GetMedia(pageNumber, pageSize int, filter MediaFilter, sort MediaSort) ([]*MediaInfo, *Page, error) {
+	var media MediaResponse
+	resp, err := o.request("media.list").SetQueryParams(map[string]string{
+		"take":   fmt.Sprintf("%d", pageSize),
+		"skip":   fmt.Sprintf("%d", pageSize*pageNumber),
+		"filter": string(filter),
+		"sort":   string(sort),
+	}).SetResult(&media).Get("/media")
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, nil, fmt.Errorf("received non-200 status code (%d)", resp.StatusCode())
+	}
+	return media.Results, &media.PageInfo, nil
+}
@@ -0,0 +1,74 @@
+package goverseerr
+
+import "net/http"
+
+// Middleware wraps the transport every API call is made through. The
+// operation name of a call is available via OperationName on the request's
+// context.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to an http.RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Use adds middleware to the client. Middleware added first is outermost.
+// Middleware should be added before the client is shared between
+// goroutines.
+func (o *Overseerr) Use(middleware ...Middleware) {
+	o.middleware = append(o.middleware, middleware...)
+	var transport http.RoundTripper = o.transport
+	for idx := len(o.middleware) - 1; idx >= 0; idx-- {
+		transport = o.middleware[idx](transport)
+	}
+	o.instrumented.next = transport
+}
+
+// RegisterPreRequestMiddleware allows for a custom function to be called
+// before each request. Useful for logging. Returning an error aborts the
+// request.
+func (o *Overseerr) RegisterPreRequestMiddleware(middleware func(req *http.Request, operation string) error) {
+	o.Use(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if err := middleware(req, OperationName(req.Context())); err != nil {
+				return nil, err
+			}
+			return next.RoundTrip(req)
+		})
+	})
+}
+
+// RegisterPostResponseMiddleware allows for a custom function to be called
+// after each request. Useful for logging. The response body must be left
+// unread. Returning an error fails the request.
+func (o *Overseerr) RegisterPostResponseMiddleware(middleware func(resp *http.Response, operation string) error) {
+	o.Use(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return resp, err
+			}
+			if err := middleware(resp, OperationName(req.Context())); err != nil {
+				resp.Body.Close()
+				return nil, err
+			}
+			return resp, nil
+		})
+	})
+}
+
+// RegisterRequestErrorMiddleware allows for a custom function to be called
+// if a request encounters an error. Useful for logging.
+func (o *Overseerr) RegisterRequestErrorMiddleware(middleware func(req *http.Request, operation string, err error)) {
+	o.Use(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				middleware(req, OperationName(req.Context()), err)
+			}
+			return resp, err
+		})
+	})
+}
@@ -1,6 +1,9 @@
 package goverseerr
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 type MovieDetails struct {
 	ID                  int                 `json:"id"`
@@ -44,9 +47,13 @@ type ProductionCompany struct {
 }
 
 func (o *Overseerr) GetMovieDetails(movieID int) (*MovieDetails, error) {
+	return o.GetMovieDetailsContext(context.Background(), movieID)
+}
+
+// GetMovieDetailsContext is GetMovieDetails with a context for the request.
+func (o *Overseerr) GetMovieDetailsContext(ctx context.Context, movieID int) (*MovieDetails, error) {
 	var details MovieDetails
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("movieID", fmt.Sprintf("%d", movieID)).
+	resp, err := o.requestContext(ctx, "movie.details").SetPathParam("movieID", fmt.Sprintf("%d", movieID)).
 		SetQueryParam("language", o.locale).SetResult(&details).Get("/movie/{movieID}")
 	if err != nil {
 		return nil, err
@@ -62,8 +69,7 @@ func (o *Overseerr) GetMovieRecommendations(movieID, page int) (*SearchResults,
 		return nil, fmt.Errorf("page number must be 1 or higher")
 	}
 	var results SearchResults
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("movieID", fmt.Sprintf("%d", movieID)).
+	resp, err := o.request("movie.recommendations").SetPathParam("movieID", fmt.Sprintf("%d", movieID)).
 		SetQueryParams(map[string]string{
 			"language": o.locale,
 			"page":     fmt.Sprintf("%d", page),
@@ -82,8 +88,7 @@ func (o *Overseerr) GetMovieSimilar(movieID, page int) (*SearchResults, error) {
 		return nil, fmt.Errorf("page number must be 1 or higher")
 	}
 	var results SearchResults
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("movieID", fmt.Sprintf("%d", movieID)).
+	resp, err := o.request("movie.similar").SetPathParam("movieID", fmt.Sprintf("%d", movieID)).
 		SetQueryParams(map[string]string{
 			"language": o.locale,
 			"page":     fmt.Sprintf("%d", page),
@@ -99,8 +104,7 @@ func (o *Overseerr) GetMovieSimilar(movieID, page int) (*SearchResults, error) {
 
 func (o *Overseerr) GetMovieRatings(movieID int) (*Rating, error) {
 	var rating Rating
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("movieID", fmt.Sprintf("%d", movieID)).
+	resp, err := o.request("movie.ratings").SetPathParam("movieID", fmt.Sprintf("%d", movieID)).
 		SetResult(&rating).Get("/movie/{movieID}/ratings")
 	if err != nil {
 		return nil, err
@@ -0,0 +1,377 @@
+package goverseerr
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type NotificationAgent string
+
+// NotificationType is a bitmask of the events an agent sends notifications
+// for.
+type NotificationType int
+
+const (
+	NotificationAgentEmail      NotificationAgent = "email"
+	NotificationAgentDiscord    NotificationAgent = "discord"
+	NotificationAgentSlack      NotificationAgent = "slack"
+	NotificationAgentTelegram   NotificationAgent = "telegram"
+	NotificationAgentPushbullet NotificationAgent = "pushbullet"
+	NotificationAgentPushover   NotificationAgent = "pushover"
+	NotificationAgentWebhook    NotificationAgent = "webhook"
+	NotificationAgentWebPush    NotificationAgent = "webpush"
+	NotificationAgentGotify     NotificationAgent = "gotify"
+	NotificationAgentLunaSea    NotificationAgent = "lunasea"
+)
+
+// NotificationAgents lists every notification agent Overseerr supports.
+var NotificationAgents = []NotificationAgent{
+	NotificationAgentEmail,
+	NotificationAgentDiscord,
+	NotificationAgentSlack,
+	NotificationAgentTelegram,
+	NotificationAgentPushbullet,
+	NotificationAgentPushover,
+	NotificationAgentWebhook,
+	NotificationAgentWebPush,
+	NotificationAgentGotify,
+	NotificationAgentLunaSea,
+}
+
+const (
+	NotificationTypeNone               NotificationType = 0
+	NotificationTypeMediaPending       NotificationType = 2
+	NotificationTypeMediaApproved      NotificationType = 4
+	NotificationTypeMediaAvailable     NotificationType = 8
+	NotificationTypeMediaFailed        NotificationType = 16
+	NotificationTypeTest               NotificationType = 32
+	NotificationTypeMediaDeclined      NotificationType = 64
+	NotificationTypeMediaAutoApproved  NotificationType = 128
+	NotificationTypeIssueCreated       NotificationType = 256
+	NotificationTypeIssueComment       NotificationType = 512
+	NotificationTypeIssueResolved      NotificationType = 1024
+	NotificationTypeIssueReopened      NotificationType = 2048
+	NotificationTypeMediaAutoRequested NotificationType = 4096
+)
+
+// NotificationAgentSettings is the envelope shared by every notification
+// agent, Options holds the agent specific settings.
+type NotificationAgentSettings[T any] struct {
+	Enabled     bool             `json:"enabled"`
+	EmbedPoster bool             `json:"embedPoster"`
+	Types       NotificationType `json:"types"`
+	Options     T                `json:"options"`
+}
+
+type EmailOptions struct {
+	EmailFrom         string `json:"emailFrom"`
+	SenderName        string `json:"senderName"`
+	SMTPHost          string `json:"smtpHost"`
+	SMTPPort          int    `json:"smtpPort"`
+	Secure            bool   `json:"secure"`
+	IgnoreTLS         bool   `json:"ignoreTls"`
+	RequireTLS        bool   `json:"requireTls"`
+	AuthUser          string `json:"authUser,omitempty"`
+	AuthPass          string `json:"authPass,omitempty"`
+	AllowSelfSigned   bool   `json:"allowSelfSigned"`
+	PGPPrivateKey     string `json:"pgpPrivateKey,omitempty"`
+	PGPPassword       string `json:"pgpPassword,omitempty"`
+	UserEmailRequired bool   `json:"userEmailRequired"`
+}
+
+type DiscordOptions struct {
+	BotUsername    string `json:"botUsername,omitempty"`
+	BotAvatarURL   string `json:"botAvatarUrl,omitempty"`
+	WebhookURL     string `json:"webhookUrl"`
+	EnableMentions bool   `json:"enableMentions"`
+}
+
+type SlackOptions struct {
+	WebhookURL string `json:"webhookUrl"`
+}
+
+type TelegramOptions struct {
+	BotUsername  string `json:"botUsername,omitempty"`
+	BotAPI       string `json:"botAPI"`
+	ChatID       string `json:"chatId"`
+	SendSilently bool   `json:"sendSilently"`
+}
+
+type PushbulletOptions struct {
+	AccessToken string `json:"accessToken"`
+	ChannelTag  string `json:"channelTag,omitempty"`
+}
+
+type PushoverOptions struct {
+	AccessToken string `json:"accessToken"`
+	UserToken   string `json:"userToken"`
+	Sound       string `json:"sound,omitempty"`
+}
+
+// WebhookOptions holds the webhook agent's settings. JSONPayload is the
+// payload template as JSON text with {{variable}} placeholders. Overseerr
+// expects the template JSON encoded as a string, e.g. "{\"event\": ...}", and
+// stores it that way, the encoding is added and removed when the options are
+// marshalled and unmarshalled.
+type WebhookOptions struct {
+	WebhookURL  string `json:"webhookUrl"`
+	AuthHeader  string `json:"authHeader,omitempty"`
+	JSONPayload string `json:"jsonPayload"`
+}
+
+type webhookOptions WebhookOptions
+
+func (w WebhookOptions) MarshalJSON() ([]byte, error) {
+	payload, err := json.Marshal(w.JSONPayload)
+	if err != nil {
+		return nil, err
+	}
+	w.JSONPayload = string(payload)
+	return json.Marshal(webhookOptions(w))
+}
+
+// UnmarshalJSON removes the string encoding from the payload template, a
+// template stored without it is kept as is.
+func (w *WebhookOptions) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*webhookOptions)(w)); err != nil {
+		return err
+	}
+	var payload string
+	if err := json.Unmarshal([]byte(w.JSONPayload), &payload); err == nil {
+		w.JSONPayload = payload
+	}
+	return nil
+}
+
+type WebPushOptions struct{}
+
+type GotifyOptions struct {
+	URL   string `json:"url"`
+	Token string `json:"token"`
+}
+
+type LunaSeaOptions struct {
+	WebhookURL  string `json:"webhookUrl"`
+	ProfileName string `json:"profileName,omitempty"`
+}
+
+// NotificationTestError is returned when Overseerr fails to send a test
+// notification through an agent.
+type NotificationTestError struct {
+	Agent      NotificationAgent
+	StatusCode int
+	Message    string
+}
+
+func (e *NotificationTestError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("test notification via %s failed (%d)", e.Agent, e.StatusCode)
+	}
+	return fmt.Sprintf("test notification via %s failed (%d): %s", e.Agent, e.StatusCode, e.Message)
+}
+
+// Has reports whether every type in t is enabled in the bitmask.
+func (n NotificationType) Has(t NotificationType) bool {
+	return n&t == t
+}
+
+func (n NotificationType) String() string {
+	names := []struct {
+		t    NotificationType
+		name string
+	}{
+		{NotificationTypeMediaPending, "media_pending"},
+		{NotificationTypeMediaApproved, "media_approved"},
+		{NotificationTypeMediaAvailable, "media_available"},
+		{NotificationTypeMediaFailed, "media_failed"},
+		{NotificationTypeTest, "test"},
+		{NotificationTypeMediaDeclined, "media_declined"},
+		{NotificationTypeMediaAutoApproved, "media_auto_approved"},
+		{NotificationTypeIssueCreated, "issue_created"},
+		{NotificationTypeIssueComment, "issue_comment"},
+		{NotificationTypeIssueResolved, "issue_resolved"},
+		{NotificationTypeIssueReopened, "issue_reopened"},
+		{NotificationTypeMediaAutoRequested, "media_auto_requested"},
+	}
+	var set []string
+	for _, entry := range names {
+		if n.Has(entry.t) {
+			set = append(set, entry.name)
+		}
+	}
+	if len(set) == 0 {
+		return "none"
+	}
+	return strings.Join(set, "|")
+}
+
+// GetNotificationSettings returns the settings for any agent, decoding its
+// options into T. Use map[string]any for T to work with options generically.
+func GetNotificationSettings[T any](o *Overseerr, agent NotificationAgent) (*NotificationAgentSettings[T], error) {
+	var settings NotificationAgentSettings[T]
+	resp, err := o.request("notifications."+string(agent)+".get").
+		SetPathParam("agent", string(agent)).
+		SetResult(&settings).Get("/settings/notifications/{agent}")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("received non-200 status code (%d)", resp.StatusCode())
+	}
+	return &settings, nil
+}
+
+// UpdateNotificationSettings saves the settings for any agent.
+func UpdateNotificationSettings[T any](o *Overseerr, agent NotificationAgent, newSettings NotificationAgentSettings[T]) (*NotificationAgentSettings[T], error) {
+	var settings NotificationAgentSettings[T]
+	resp, err := o.request("notifications."+string(agent)+".update").
+		SetPathParam("agent", string(agent)).
+		SetBody(newSettings).SetResult(&settings).Post("/settings/notifications/{agent}")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("received non-200 status code (%d)", resp.StatusCode())
+	}
+	return &settings, nil
+}
+
+// TestNotificationAgent asks Overseerr to send a test notification using the
+// given settings, which need not be saved. A *NotificationTestError is
+// returned if the notification could not be sent.
+func (o *Overseerr) TestNotificationAgent(agent NotificationAgent, settings any) error {
+	var failure struct {
+		Message string `json:"message"`
+	}
+	resp, err := o.request("notifications."+string(agent)+".test").
+		SetPathParam("agent", string(agent)).
+		SetBody(settings).SetError(&failure).Post("/settings/notifications/{agent}/test")
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != 204 {
+		return &NotificationTestError{Agent: agent, StatusCode: resp.StatusCode(), Message: failure.Message}
+	}
+	return nil
+}
+
+func (o *Overseerr) GetEmailNotificationSettings() (*NotificationAgentSettings[EmailOptions], error) {
+	return GetNotificationSettings[EmailOptions](o, NotificationAgentEmail)
+}
+
+func (o *Overseerr) UpdateEmailNotificationSettings(settings NotificationAgentSettings[EmailOptions]) (*NotificationAgentSettings[EmailOptions], error) {
+	return UpdateNotificationSettings(o, NotificationAgentEmail, settings)
+}
+
+func (o *Overseerr) TestEmailNotificationSettings(settings NotificationAgentSettings[EmailOptions]) error {
+	return o.TestNotificationAgent(NotificationAgentEmail, settings)
+}
+
+func (o *Overseerr) GetDiscordNotificationSettings() (*NotificationAgentSettings[DiscordOptions], error) {
+	return GetNotificationSettings[DiscordOptions](o, NotificationAgentDiscord)
+}
+
+func (o *Overseerr) UpdateDiscordNotificationSettings(settings NotificationAgentSettings[DiscordOptions]) (*NotificationAgentSettings[DiscordOptions], error) {
+	return UpdateNotificationSettings(o, NotificationAgentDiscord, settings)
+}
+
+func (o *Overseerr) TestDiscordNotificationSettings(settings NotificationAgentSettings[DiscordOptions]) error {
+	return o.TestNotificationAgent(NotificationAgentDiscord, settings)
+}
+
+func (o *Overseerr) GetSlackNotificationSettings() (*NotificationAgentSettings[SlackOptions], error) {
+	return GetNotificationSettings[SlackOptions](o, NotificationAgentSlack)
+}
+
+func (o *Overseerr) UpdateSlackNotificationSettings(settings NotificationAgentSettings[SlackOptions]) (*NotificationAgentSettings[SlackOptions], error) {
+	return UpdateNotificationSettings(o, NotificationAgentSlack, settings)
+}
+
+func (o *Overseerr) TestSlackNotificationSettings(settings NotificationAgentSettings[SlackOptions]) error {
+	return o.TestNotificationAgent(NotificationAgentSlack, settings)
+}
+
+func (o *Overseerr) GetTelegramNotificationSettings() (*NotificationAgentSettings[TelegramOptions], error) {
+	return GetNotificationSettings[TelegramOptions](o, NotificationAgentTelegram)
+}
+
+func (o *Overseerr) UpdateTelegramNotificationSettings(settings NotificationAgentSettings[TelegramOptions]) (*NotificationAgentSettings[TelegramOptions], error) {
+	return UpdateNotificationSettings(o, NotificationAgentTelegram, settings)
+}
+
+func (o *Overseerr) TestTelegramNotificationSettings(settings NotificationAgentSettings[TelegramOptions]) error {
+	return o.TestNotificationAgent(NotificationAgentTelegram, settings)
+}
+
+func (o *Overseerr) GetPushbulletNotificationSettings() (*NotificationAgentSettings[PushbulletOptions], error) {
+	return GetNotificationSettings[PushbulletOptions](o, NotificationAgentPushbullet)
+}
+
+func (o *Overseerr) UpdatePushbulletNotificationSettings(settings NotificationAgentSettings[PushbulletOptions]) (*NotificationAgentSettings[PushbulletOptions], error) {
+	return UpdateNotificationSettings(o, NotificationAgentPushbullet, settings)
+}
+
+func (o *Overseerr) TestPushbulletNotificationSettings(settings NotificationAgentSettings[PushbulletOptions]) error {
+	return o.TestNotificationAgent(NotificationAgentPushbullet, settings)
+}
+
+func (o *Overseerr) GetPushoverNotificationSettings() (*NotificationAgentSettings[PushoverOptions], error) {
+	return GetNotificationSettings[PushoverOptions](o, NotificationAgentPushover)
+}
+
+func (o *Overseerr) UpdatePushoverNotificationSettings(settings NotificationAgentSettings[PushoverOptions]) (*NotificationAgentSettings[PushoverOptions], error) {
+	return UpdateNotificationSettings(o, NotificationAgentPushover, settings)
+}
+
+func (o *Overseerr) TestPushoverNotificationSettings(settings NotificationAgentSettings[PushoverOptions]) error {
+	return o.TestNotificationAgent(NotificationAgentPushover, settings)
+}
+
+func (o *Overseerr) GetWebhookNotificationSettings() (*NotificationAgentSettings[WebhookOptions], error) {
+	return GetNotificationSettings[WebhookOptions](o, NotificationAgentWebhook)
+}
+
+func (o *Overseerr) UpdateWebhookNotificationSettings(settings NotificationAgentSettings[WebhookOptions]) (*NotificationAgentSettings[WebhookOptions], error) {
+	return UpdateNotificationSettings(o, NotificationAgentWebhook, settings)
+}
+
+func (o *Overseerr) TestWebhookNotificationSettings(settings NotificationAgentSettings[WebhookOptions]) error {
+	return o.TestNotificationAgent(NotificationAgentWebhook, settings)
+}
+
+func (o *Overseerr) GetWebPushNotificationSettings() (*NotificationAgentSettings[WebPushOptions], error) {
+	return GetNotificationSettings[WebPushOptions](o, NotificationAgentWebPush)
+}
+
+func (o *Overseerr) UpdateWebPushNotificationSettings(settings NotificationAgentSettings[WebPushOptions]) (*NotificationAgentSettings[WebPushOptions], error) {
+	return UpdateNotificationSettings(o, NotificationAgentWebPush, settings)
+}
+
+func (o *Overseerr) TestWebPushNotificationSettings(settings NotificationAgentSettings[WebPushOptions]) error {
+	return o.TestNotificationAgent(NotificationAgentWebPush, settings)
+}
+
+func (o *Overseerr) GetGotifyNotificationSettings() (*NotificationAgentSettings[GotifyOptions], error) {
+	return GetNotificationSettings[GotifyOptions](o, NotificationAgentGotify)
+}
+
+func (o *Overseerr) UpdateGotifyNotificationSettings(settings NotificationAgentSettings[GotifyOptions]) (*NotificationAgentSettings[GotifyOptions], error) {
+	return UpdateNotificationSettings(o, NotificationAgentGotify, settings)
+}
+
+func (o *Overseerr) TestGotifyNotificationSettings(settings NotificationAgentSettings[GotifyOptions]) error {
+	return o.TestNotificationAgent(NotificationAgentGotify, settings)
+}
+
+func (o *Overseerr) GetLunaSeaNotificationSettings() (*NotificationAgentSettings[LunaSeaOptions], error) {
+	return GetNotificationSettings[LunaSeaOptions](o, NotificationAgentLunaSea)
+}
+
+func (o *Overseerr) UpdateLunaSeaNotificationSettings(settings NotificationAgentSettings[LunaSeaOptions]) (*NotificationAgentSettings[LunaSeaOptions], error) {
+	return UpdateNotificationSettings(o, NotificationAgentLunaSea, settings)
+}
+
+func (o *Overseerr) TestLunaSeaNotificationSettings(settings NotificationAgentSettings[LunaSeaOptions]) error {
+	return o.TestNotificationAgent(NotificationAgentLunaSea, settings)
+}
@@ -0,0 +1,41 @@
+package goverseerr
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWebhookOptionsJSON(t *testing.T) {
+	template := "{\n  \"event\": \"{{event}}\"\n}"
+	data, err := json.Marshal(WebhookOptions{WebhookURL: "http://example.com", JSONPayload: template})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sent map[string]string
+	if err := json.Unmarshal(data, &sent); err != nil {
+		t.Fatal(err)
+	}
+	var decoded string
+	if err := json.Unmarshal([]byte(sent["jsonPayload"]), &decoded); err != nil {
+		t.Fatalf("jsonPayload is not a JSON encoded string: %v", err)
+	}
+	if decoded != template {
+		t.Fatalf("jsonPayload decodes to %q, want %q", decoded, template)
+	}
+
+	var options WebhookOptions
+	if err := json.Unmarshal(data, &options); err != nil {
+		t.Fatal(err)
+	}
+	if options.JSONPayload != template {
+		t.Fatalf("JSONPayload = %q, want %q", options.JSONPayload, template)
+	}
+
+	raw := []byte(`{"webhookUrl": "http://example.com", "jsonPayload": "{\"event\": \"{{event}}\"}"}`)
+	if err := json.Unmarshal(raw, &options); err != nil {
+		t.Fatal(err)
+	}
+	if options.JSONPayload != `{"event": "{{event}}"}` {
+		t.Fatalf("unencoded JSONPayload = %q, want it kept as is", options.JSONPayload)
+	}
+}
@@ -0,0 +1,63 @@
+// Package otel provides an OpenTelemetry tracing hook for goverseerr
+// clients.
+package otel
+
+import (
+	"context"
+
+	"github.com/willfantom/goverseerr"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName string = "github.com/willfantom/goverseerr"
+
+// TracingHook is a goverseerr.Hook that wraps every API call in a client
+// span named after the operation.
+type TracingHook struct {
+	tracer trace.Tracer
+}
+
+// NewTracingHook creates a TracingHook using the given tracer provider, or
+// the global provider if nil.
+func NewTracingHook(provider trace.TracerProvider) *TracingHook {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return &TracingHook{tracer: provider.Tracer(instrumentationName)}
+}
+
+func (h *TracingHook) OperationStarted(ctx context.Context, op goverseerr.OperationInfo) context.Context {
+	name := op.Name
+	if name == "" {
+		name = op.Method + " " + op.Path
+	}
+	ctx, _ = h.tracer.Start(ctx, "overseerr."+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("overseerr.operation", op.Name),
+			attribute.String("http.request.method", op.Method),
+			attribute.String("url.path", op.Path),
+		),
+	)
+	return ctx
+}
+
+func (h *TracingHook) OperationFinished(ctx context.Context, op goverseerr.OperationInfo, result goverseerr.OperationResult) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+	if result.StatusCode > 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", result.StatusCode))
+	}
+	if result.ErrorClass != goverseerr.ErrorClassNone {
+		span.SetAttributes(attribute.String("error.type", string(result.ErrorClass)))
+		if result.Err != nil {
+			span.RecordError(result.Err)
+			span.SetStatus(codes.Error, result.Err.Error())
+		} else {
+			span.SetStatus(codes.Error, string(result.ErrorClass))
+		}
+	}
+}
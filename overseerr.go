@@ -1,9 +1,13 @@
 package goverseerr
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/go-resty/resty/v2"
 )
@@ -14,9 +18,16 @@ const (
 )
 
 type Overseerr struct {
-	URL        string
-	restClient *resty.Client
-	locale     string
+	URL          string
+	restClient   *resty.Client
+	locale       string
+	detailsCache *detailsCache
+	transport    *http.Transport
+	hooks        []Hook
+	middleware   []Middleware
+	instrumented *instrumentedTransport
+	apiKey       atomic.Pointer[string]
+	rotateMu     sync.Mutex
 }
 
 func new(url string, customHeaders map[string]string, locale string) *Overseerr {
@@ -28,6 +39,16 @@ func new(url string, customHeaders map[string]string, locale string) *Overseerr
 	oversr.restClient.SetHostURL(url + apiPrefix)
 	oversr.restClient.SetHeaders(customHeaders)
 	oversr.locale = locale
+	oversr.transport = http.DefaultTransport.(*http.Transport).Clone()
+	oversr.instrumented = &instrumentedTransport{o: &oversr, next: oversr.transport}
+	oversr.restClient.SetTransport(oversr.instrumented)
+	oversr.detailsCache = newDetailsCache(defaultDetailsCacheTTL)
+	oversr.restClient.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		if key := oversr.apiKey.Load(); key != nil {
+			req.SetHeader("X-Api-Key", *key)
+		}
+		return nil
+	})
 	return &oversr
 }
 
@@ -35,7 +56,7 @@ func new(url string, customHeaders map[string]string, locale string) *Overseerr
 // An error is returned alongside the client if an auth check fails.
 func NewKeyAuth(url string, customHeaders map[string]string, locale string, apikey string) (*Overseerr, error) {
 	o := new(url, customHeaders, locale)
-	o.restClient.SetHeader("X-Api-Key", apikey)
+	o.apiKey.Store(&apikey)
 	_, err := o.GetLoggedInUser()
 	return o, err
 }
@@ -45,8 +66,7 @@ func NewKeyAuth(url string, customHeaders map[string]string, locale string, apik
 // An error is returned alongside the client if an auth check fails.
 func NewLocalAuth(url string, customHeaders map[string]string, locale string, email, password string) (*Overseerr, error) {
 	o := new(url, customHeaders, locale)
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("auth.local").
 		SetBody(map[string]string{
 			"email":    email,
 			"password": password,
@@ -71,8 +91,7 @@ func NewLocalAuth(url string, customHeaders map[string]string, locale string, em
 // An error is returned alongside the client if an auth check fails.
 func NewPlexAuth(url string, customHeaders map[string]string, locale string, plexToken string) (*Overseerr, error) {
 	o := new(url, customHeaders, locale)
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("auth.plex").
 		SetBody(map[string]string{
 			"authToken": plexToken,
 		}).Post("/auth/plex")
@@ -92,19 +111,28 @@ func NewPlexAuth(url string, customHeaders map[string]string, locale string, ple
 }
 
 type Status struct {
-	Version   string `json:"version"`
-	CommitTag string `json:"commitTag"`
+	Version         string `json:"version"`
+	CommitTag       string `json:"commitTag"`
+	UpdateAvailable bool   `json:"updateAvailable"`
+	CommitsBehind   int    `json:"commitsBehind"`
+	RestartRequired bool   `json:"restartRequired"`
 }
 
+// AppData describes the config directory. Configured reports whether the
+// directory is mounted and Writable whether Overseerr can write to it.
 type AppData struct {
 	Configured bool   `json:"appData"`
 	Path       string `json:"appDataPath"`
+	Writable   bool   `json:"appDataPermissions"`
 }
 
 func (o *Overseerr) Status() (*Status, error) {
+	return o.status(context.Background())
+}
+
+func (o *Overseerr) status(ctx context.Context) (*Status, error) {
 	var status Status
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.requestContext(ctx, "status.get").
 		SetResult(&status).Get("/status")
 	if err != nil {
 		return nil, err
@@ -115,10 +143,39 @@ func (o *Overseerr) Status() (*Status, error) {
 	return &status, nil
 }
 
+// WaitForReady polls the server status until it responds, e.g. after a
+// restart or upgrade. Given the status from before the restart was
+// triggered, it waits until the server has gone down and come back up or
+// reports a different version, as the old process usually keeps answering
+// for a while. A restart quicker than the poll interval that keeps the
+// version can be missed, so ctx should carry a deadline. The last error seen
+// is returned if ctx is done first.
+func (o *Overseerr) WaitForReady(ctx context.Context, previous *Status) (*Status, error) {
+	wait := newBackoff()
+	down := false
+	var lastErr error
+	for {
+		status, err := o.status(ctx)
+		switch {
+		case err != nil:
+			down = true
+			lastErr = err
+		case previous == nil || down || status.Version != previous.Version || status.CommitTag != previous.CommitTag:
+			return status, nil
+		default:
+			// keep polling quickly until the old process goes away
+			wait = newBackoff()
+			lastErr = fmt.Errorf("server has not restarted")
+		}
+		if waitErr := wait.wait(ctx); waitErr != nil {
+			return nil, fmt.Errorf("%w (last error: %v)", waitErr, lastErr)
+		}
+	}
+}
+
 func (o *Overseerr) GetAppData() (*AppData, error) {
 	var appdata AppData
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("status.appdata").
 		SetResult(&appdata).Get("/status/appdata")
 	if err != nil {
 		return nil, err
@@ -129,33 +186,32 @@ func (o *Overseerr) GetAppData() (*AppData, error) {
 	return &appdata, nil
 }
 
-// RegisterPreRequestMiddleware allows for a custom function to be called
-// before each request. Useful for logging.
-func (o *Overseerr) RegisterPreRequestMiddleware(middleware func(c *resty.Client, req *resty.Request) error) {
-	o.restClient.OnBeforeRequest(middleware)
-}
-
-// RegisterPostResponseMiddleware allows for a custom function to be called
-// after each request. Useful for logging.
-func (o *Overseerr) RegisterPostResponseMiddleware(middleware func(c *resty.Client, resp *resty.Response) error) {
-	o.restClient.OnAfterResponse(middleware)
+// Check returns an error if the config directory is not mounted or not
+// writable.
+func (a *AppData) Check() error {
+	if !a.Configured {
+		return fmt.Errorf("config directory %s is not mounted", a.Path)
+	}
+	if !a.Writable {
+		return fmt.Errorf("config directory %s is not writable", a.Path)
+	}
+	return nil
 }
 
-// RegisterRequestErrorMiddleware allows for a custom function to be called
-// if a request encounters an error. Useful for logging.
-func (o *Overseerr) RegisterRequestErrorMiddleware(middleware func(req *resty.Request, err error)) {
-	o.restClient.OnError(middleware)
+// Locale returns the language code the client requests content in.
+func (o *Overseerr) Locale() string {
+	return o.locale
 }
 
 // SetProxy forces all requests to the Overseerr to go via the given proxy
 // and removes the proxy if a blank string is given
 func (o *Overseerr) SetProxy(proxyURL string) error {
 	if proxyURL == "" {
-		o.restClient.RemoveProxy()
+		o.transport.Proxy = nil
 		return nil
 	}
-	if _, err := url.Parse(proxyURL); err == nil {
-		o.restClient.SetProxy(proxyURL)
+	if u, err := url.Parse(proxyURL); err == nil {
+		o.transport.Proxy = http.ProxyURL(u)
 		return nil
 	}
 	return fmt.Errorf("proxy url is not valid")
@@ -0,0 +1,113 @@
+package goverseerr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client for a fake Overseerr served by handler. The
+// auth check made when creating the client is answered by the fake itself.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Overseerr {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/auth/me" {
+			json.NewEncoder(w).Encode(map[string]any{"id": 1})
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	o, err := NewKeyAuth(server.URL, nil, "en", "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
+
+func TestStatusDecoding(t *testing.T) {
+	o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/status":
+			w.Write([]byte(`{"version": "1.33.2", "commitTag": "abc123", "updateAvailable": true, "commitsBehind": 4, "restartRequired": true}`))
+		case "/api/v1/status/appdata":
+			w.Write([]byte(`{"appData": true, "appDataPath": "/app/config", "appDataPermissions": false}`))
+		}
+	})
+	status, err := o.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Status{Version: "1.33.2", CommitTag: "abc123", UpdateAvailable: true, CommitsBehind: 4, RestartRequired: true}
+	if *status != want {
+		t.Errorf("status = %+v, want %+v", *status, want)
+	}
+	appData, err := o.GetAppData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !appData.Configured || appData.Writable || appData.Path != "/app/config" {
+		t.Errorf("appdata = %+v, want configured but not writable", *appData)
+	}
+	if err := appData.Check(); err == nil || !strings.Contains(err.Error(), "not writable") {
+		t.Errorf("Check() = %v, want a not writable error", err)
+	}
+}
+
+func TestWaitForReady(t *testing.T) {
+	old := &Status{Version: "1.0.0", CommitTag: "old"}
+	tests := []struct {
+		name      string
+		previous  *Status
+		responses []string
+		want      string
+	}{
+		{name: "no previous status", responses: []string{"1.0.0"}, want: "1.0.0"},
+		{name: "restarted", previous: old, responses: []string{"1.0.0", "", "1.0.0"}, want: "1.0.0"},
+		{name: "upgraded", previous: old, responses: []string{"1.0.0", "1.1.0"}, want: "1.1.0"},
+		{name: "never restarted", previous: old, responses: []string{"1.0.0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				// the last response repeats once they run out
+				version := tt.responses[min(calls, len(tt.responses)-1)]
+				calls++
+				if version == "" {
+					http.Error(w, "{}", http.StatusServiceUnavailable)
+					return
+				}
+				commitTag := "old"
+				if version != old.Version {
+					commitTag = "new"
+				}
+				json.NewEncoder(w).Encode(Status{Version: version, CommitTag: commitTag})
+			})
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			defer cancel()
+			if tt.want == "" {
+				ctx, cancel = context.WithTimeout(ctx, 200*time.Millisecond)
+				defer cancel()
+			}
+			status, err := o.WaitForReady(ctx, tt.previous)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("WaitForReady() = %+v, want an error while the old server keeps answering", status)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.Version != tt.want || calls != len(tt.responses) {
+				t.Errorf("version = %s after %d calls, want %s after %d", status.Version, calls, tt.want, len(tt.responses))
+			}
+		})
+	}
+}
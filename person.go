@@ -19,8 +19,7 @@ type PersonDetails struct {
 
 func (o *Overseerr) GetPersonDetails(personID int) (*PersonDetails, error) {
 	var details PersonDetails
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("personID", fmt.Sprintf("%d", personID)).
+	resp, err := o.request("person.details").SetPathParam("personID", fmt.Sprintf("%d", personID)).
 		SetQueryParam("language", o.locale).SetResult(&details).Get("/person/{personID}")
 	if err != nil {
 		return nil, err
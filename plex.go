@@ -1,35 +1,56 @@
 package goverseerr
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 )
 
 type PlexDevice struct {
-	Name                   string         `json:"name"`
-	Product                string         `json:"product"`
-	ProductVersion         string         `json:"productVersion"`
-	Platform               string         `json:"platform"`
-	PlatformVersion        string         `json:"platformVersion"`
-	Device                 string         `json:"device"`
-	ClientIdentifier       string         `json:"clientIdentifier"`
-	Created                time.Time      `json:"createdAt"`
-	LastSeen               time.Time      `json:"lastSeenAt"`
-	Provides               []string       `json:"provides"`
-	Owned                  bool           `json:"owned"`
-	OwnerID                string         `json:"ownerId"`
-	Home                   bool           `json:"home"`
-	SourceTitle            string         `json:"sourceTitle"`
-	AccessToken            string         `json:"accessToken"`
-	PublicAddress          string         `json:"publicAddress"`
-	HTTPSRequired          bool           `json:"httpsRequired"`
-	Synced                 bool           `json:"synced"`
-	Relay                  bool           `json:"relay"`
-	DNSRebindingProtection bool           `json:"dnsRebindingProtection"`
-	NATLoopbackSupported   bool           `json:"natLoopbackSupported"`
-	PublicAddressMatches   bool           `json:"publicAddressMatches"`
-	Presence               bool           `json:"presence"`
-	Connection             PlexConnection `json:"connection"`
+	Name                   string           `json:"name"`
+	Product                string           `json:"product"`
+	ProductVersion         string           `json:"productVersion"`
+	Platform               string           `json:"platform"`
+	PlatformVersion        string           `json:"platformVersion"`
+	Device                 string           `json:"device"`
+	ClientIdentifier       string           `json:"clientIdentifier"`
+	Created                time.Time        `json:"createdAt"`
+	LastSeen               time.Time        `json:"lastSeenAt"`
+	Provides               []string         `json:"provides"`
+	Owned                  bool             `json:"owned"`
+	OwnerID                string           `json:"ownerId"`
+	Home                   bool             `json:"home"`
+	SourceTitle            string           `json:"sourceTitle"`
+	AccessToken            string           `json:"accessToken"`
+	PublicAddress          string           `json:"publicAddress"`
+	HTTPSRequired          bool             `json:"httpsRequired"`
+	Synced                 bool             `json:"synced"`
+	Relay                  bool             `json:"relay"`
+	DNSRebindingProtection bool             `json:"dnsRebindingProtection"`
+	NATLoopbackSupported   bool             `json:"natLoopbackSupported"`
+	PublicAddressMatches   bool             `json:"publicAddressMatches"`
+	Presence               bool             `json:"presence"`
+	Connections            []PlexConnection `json:"connection"`
+}
+
+// PreferredConnection returns a connection to the device that Overseerr
+// could reach, preferring local ones, or nil if none were reachable.
+func (d *PlexDevice) PreferredConnection() *PlexConnection {
+	var preferred *PlexConnection
+	for idx := range d.Connections {
+		conn := &d.Connections[idx]
+		if conn.Status != 200 {
+			continue
+		}
+		if conn.Local {
+			return conn
+		}
+		if preferred == nil {
+			preferred = conn
+		}
+	}
+	return preferred
 }
 
 type PlexConnection struct {
@@ -53,6 +74,7 @@ type PlexSettings struct {
 	MachineID string        `json:"machineId"`
 	IP        string        `json:"ip"`
 	Port      int           `json:"port"`
+	UseSSL    bool          `json:"useSsl"`
 	Libraries []PlexLibrary `json:"libraries"`
 	WebAppURL string        `json:"webAppUrl"`
 }
@@ -67,8 +89,7 @@ type PlexSyncStatus struct {
 
 func (o *Overseerr) GetPlexSettings() (*PlexSettings, error) {
 	var settings PlexSettings
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("plex.settings.get").
 		SetResult(&settings).Get("/settings/plex")
 	if err != nil {
 		return nil, err
@@ -80,8 +101,7 @@ func (o *Overseerr) GetPlexSettings() (*PlexSettings, error) {
 }
 
 func (o *Overseerr) UpdatePlexSettings(newSettings PlexSettings) error {
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("plex.settings.update").
 		SetBody(newSettings).Post("/settings/plex")
 	if err != nil {
 		return err
@@ -94,8 +114,38 @@ func (o *Overseerr) UpdatePlexSettings(newSettings PlexSettings) error {
 
 func (o *Overseerr) GetPlexLibraries() ([]*PlexLibrary, error) {
 	var libraries []*PlexLibrary
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("plex.libraries").
+		SetResult(&libraries).Get("/settings/plex/library")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("received non-200 status code (%d)", resp.StatusCode())
+	}
+	return libraries, nil
+}
+
+// RefreshPlexLibraries reloads the library list from the Plex server.
+func (o *Overseerr) RefreshPlexLibraries() ([]*PlexLibrary, error) {
+	var libraries []*PlexLibrary
+	resp, err := o.request("plex.libraries.refresh").
+		SetQueryParam("sync", "true").
+		SetResult(&libraries).Get("/settings/plex/library")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("received non-200 status code (%d)", resp.StatusCode())
+	}
+	return libraries, nil
+}
+
+// EnablePlexLibraries enables the libraries with the given IDs and disables
+// all others.
+func (o *Overseerr) EnablePlexLibraries(libraryIDs []string) ([]*PlexLibrary, error) {
+	var libraries []*PlexLibrary
+	resp, err := o.request("plex.libraries.enable").
+		SetQueryParam("enable", strings.Join(libraryIDs, ",")).
 		SetResult(&libraries).Get("/settings/plex/library")
 	if err != nil {
 		return nil, err
@@ -107,9 +157,13 @@ func (o *Overseerr) GetPlexLibraries() ([]*PlexLibrary, error) {
 }
 
 func (o *Overseerr) GetPlexSyncStatus() (*PlexSyncStatus, error) {
+	return o.GetPlexSyncStatusContext(context.Background())
+}
+
+// GetPlexSyncStatusContext is GetPlexSyncStatus with a context for the request.
+func (o *Overseerr) GetPlexSyncStatusContext(ctx context.Context) (*PlexSyncStatus, error) {
 	var status PlexSyncStatus
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.requestContext(ctx, "plex.sync.status").
 		SetResult(&status).Get("/settings/plex/sync")
 	if err != nil {
 		return nil, err
@@ -122,8 +176,7 @@ func (o *Overseerr) GetPlexSyncStatus() (*PlexSyncStatus, error) {
 
 func (o *Overseerr) GetPlexServers() ([]*PlexDevice, error) {
 	var devices []*PlexDevice
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("plex.servers").
 		SetResult(&devices).Get("/settings/plex/devices/servers")
 	if err != nil {
 		return nil, err
@@ -135,8 +188,7 @@ func (o *Overseerr) GetPlexServers() ([]*PlexDevice, error) {
 }
 
 func (o *Overseerr) TriggerPlexSync() error {
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("plex.sync.start").
 		SetBody(map[string]bool{
 			"start":  true,
 			"cancel": false,
@@ -151,8 +203,7 @@ func (o *Overseerr) TriggerPlexSync() error {
 }
 
 func (o *Overseerr) CancelPlexSync() error {
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("plex.sync.cancel").
 		SetBody(map[string]bool{
 			"start":  false,
 			"cancel": true,
@@ -165,3 +216,54 @@ func (o *Overseerr) CancelPlexSync() error {
 	}
 	return nil
 }
+
+type PlexSyncSummary struct {
+	Started   time.Time
+	Finished  time.Time
+	Duration  time.Duration
+	Total     int
+	Libraries []PlexLibrary
+	Cancelled bool
+}
+
+// SyncPlexAndWait triggers a full Plex library sync and polls until it has
+// finished, passing each status to progress if given. If the context is
+// cancelled first the sync is cancelled and the context's error is returned
+// alongside the summary.
+func (o *Overseerr) SyncPlexAndWait(ctx context.Context, progress func(PlexSyncStatus)) (*PlexSyncSummary, error) {
+	summary := PlexSyncSummary{Started: time.Now()}
+	finish := func() *PlexSyncSummary {
+		summary.Finished = time.Now()
+		summary.Duration = summary.Finished.Sub(summary.Started)
+		return &summary
+	}
+	if err := o.TriggerPlexSync(); err != nil {
+		return nil, err
+	}
+	wait := newBackoff()
+	for {
+		if err := wait.wait(ctx); err != nil {
+			summary.Cancelled = true
+			if cancelErr := o.CancelPlexSync(); cancelErr != nil {
+				return finish(), fmt.Errorf("%w (failed to cancel sync: %v)", err, cancelErr)
+			}
+			return finish(), err
+		}
+		status, err := o.GetPlexSyncStatus()
+		if err != nil {
+			return finish(), err
+		}
+		if status.Total > summary.Total {
+			summary.Total = status.Total
+		}
+		if len(status.Libraries) > 0 {
+			summary.Libraries = status.Libraries
+		}
+		if progress != nil {
+			progress(*status)
+		}
+		if !status.Running {
+			return finish(), nil
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package goverseerr
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPlexDevicePreferredConnection(t *testing.T) {
+	var device PlexDevice
+	err := json.Unmarshal([]byte(`{
+		"name": "plex",
+		"connection": [
+			{"protocol": "https", "address": "203.0.113.5", "port": 32400, "local": false, "status": 200},
+			{"protocol": "http", "address": "10.0.0.2", "port": 32400, "local": true, "status": 500},
+			{"protocol": "http", "address": "10.0.0.3", "port": 32400, "local": true, "status": 200}
+		]
+	}`), &device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conn := device.PreferredConnection(); conn == nil || conn.Address != "10.0.0.3" {
+		t.Fatalf("PreferredConnection() = %+v, want the reachable local connection", conn)
+	}
+
+	device.Connections[2].Status = 408
+	if conn := device.PreferredConnection(); conn == nil || conn.Address != "203.0.113.5" {
+		t.Fatalf("PreferredConnection() = %+v, want the reachable remote connection", conn)
+	}
+
+	device.Connections[0].Status = 0
+	if conn := device.PreferredConnection(); conn != nil {
+		t.Fatalf("PreferredConnection() = %+v, want nil when nothing is reachable", conn)
+	}
+}
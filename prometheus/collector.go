@@ -0,0 +1,359 @@
+// Package prometheus exposes the state of an Overseerr instance as
+// Prometheus metrics.
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/willfantom/goverseerr"
+)
+
+const usersPageSize int = 100
+
+// Options configures a Collector. Each Enable flag turns on a group of
+// metrics, DefaultOptions enables them all.
+type Options struct {
+	// Prefix is the namespace all metric names start with.
+	Prefix string
+	// Timeout bounds a single scrape, groups still running when it expires
+	// are reported as failed and their requests cancelled.
+	Timeout time.Duration
+
+	EnableRequests bool
+	EnableAbout    bool
+	EnableCache    bool
+	EnableJobs     bool
+	EnablePlexSync bool
+	EnableUsers    bool
+	EnableQuotas   bool
+	// EnableUserInfo adds a user_info metric mapping the user IDs other user
+	// metrics are labelled with to emails.
+	EnableUserInfo bool
+}
+
+// Collector implements prometheus.Collector for an Overseerr instance.
+type Collector struct {
+	client *goverseerr.Overseerr
+	opts   Options
+	descs  map[string]*prometheus.Desc
+	groups []group
+}
+
+type group struct {
+	name    string
+	collect func(ctx context.Context) ([]prometheus.Metric, error)
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Prefix:         "overseerr",
+		Timeout:        10 * time.Second,
+		EnableRequests: true,
+		EnableAbout:    true,
+		EnableCache:    true,
+		EnableJobs:     true,
+		EnablePlexSync: true,
+		EnableUsers:    true,
+		EnableQuotas:   true,
+	}
+}
+
+// NewCollector creates a collector for the Overseerr client. Register it
+// with a prometheus.Registerer to expose its metrics.
+func NewCollector(client *goverseerr.Overseerr, opts Options) *Collector {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	c := &Collector{client: client, opts: opts, descs: make(map[string]*prometheus.Desc)}
+	c.desc("scrape_error", "Whether the last scrape of a metric group failed (1) or succeeded (0).", "collector")
+	c.desc("scrape_duration_seconds", "Time taken to scrape a metric group.", "collector")
+	if opts.EnableRequests {
+		c.desc("requests", "Number of requests by status.", "status")
+		c.groups = append(c.groups, group{"requests", c.collectRequests})
+	}
+	if opts.EnableAbout {
+		c.desc("info", "Overseerr server information.", "version", "timezone")
+		c.desc("requests_total", "Total number of requests ever made.")
+		c.desc("media_items_total", "Total number of media items.")
+		c.groups = append(c.groups, group{"about", c.collectAbout})
+	}
+	if opts.EnableCache {
+		c.desc("cache_hits", "API cache hits.", "cache")
+		c.desc("cache_misses", "API cache misses.", "cache")
+		c.desc("cache_keys", "API cache keys.", "cache")
+		c.desc("cache_key_size_bytes", "API cache approximate key size.", "cache")
+		c.desc("cache_value_size_bytes", "API cache approximate value size.", "cache")
+		c.desc("image_cache_size_bytes", "Image cache size on disk.", "source")
+		c.desc("image_cache_images", "Number of images in the image cache.", "source")
+		c.groups = append(c.groups, group{"cache", c.collectCache})
+	}
+	if opts.EnableJobs {
+		c.desc("job_running", "Whether a job is currently running.", "job", "name")
+		c.desc("job_next_run_timestamp_seconds", "Time of a job's next scheduled run.", "job", "name")
+		c.groups = append(c.groups, group{"jobs", c.collectJobs})
+	}
+	if opts.EnablePlexSync {
+		c.desc("plex_sync_running", "Whether a Plex library sync is running.")
+		c.desc("plex_sync_progress", "Items processed by the current Plex sync.")
+		c.desc("plex_sync_total", "Items to process in the current Plex sync.")
+		c.groups = append(c.groups, group{"plex_sync", c.collectPlexSync})
+	}
+	if opts.EnableUsers {
+		c.desc("users", "Number of users by type.", "type")
+		c.desc("user_requests", "Number of requests made by a user.", "user")
+		if opts.EnableUserInfo {
+			c.desc("user_info", "Maps a user ID to the user's email.", "user", "email")
+		}
+		c.groups = append(c.groups, group{"users", c.collectUsers})
+	}
+	if opts.EnableQuotas {
+		c.desc("user_quota_limit", "A user's request quota limit.", "user", "media")
+		c.desc("user_quota_used", "A user's used request quota.", "user", "media")
+		c.desc("user_quota_remaining", "A user's remaining request quota.", "user", "media")
+		c.groups = append(c.groups, group{"quotas", c.collectQuotas})
+	}
+	return c
+}
+
+func (c *Collector) desc(name, help string, labels ...string) {
+	c.descs[name] = prometheus.NewDesc(prometheus.BuildFQName(c.opts.Prefix, "", name), help, labels, nil)
+}
+
+func (c *Collector) gauge(name string, value float64, labels ...string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(c.descs[name], prometheus.GaugeValue, value, labels...)
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range c.descs {
+		ch <- desc
+	}
+}
+
+// Collect scrapes each enabled group concurrently. Groups that do not
+// finish before the timeout only report their scrape error metric, groups
+// that error report it alongside any metrics they did collect.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	type result struct {
+		metrics  []prometheus.Metric
+		err      error
+		duration time.Duration
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), c.opts.Timeout)
+	defer cancel()
+	results := make([]chan result, len(c.groups))
+	for idx, g := range c.groups {
+		results[idx] = make(chan result, 1)
+		go func(g group, out chan<- result) {
+			start := time.Now()
+			metrics, err := g.collect(ctx)
+			out <- result{metrics: metrics, err: err, duration: time.Since(start)}
+		}(g, results[idx])
+	}
+	timedOut := false
+	for idx, g := range c.groups {
+		var res result
+		if timedOut {
+			// only take results that are already in
+			select {
+			case res = <-results[idx]:
+			default:
+				res = result{err: fmt.Errorf("timed out"), duration: c.opts.Timeout}
+			}
+		} else {
+			select {
+			case res = <-results[idx]:
+			case <-ctx.Done():
+				timedOut = true
+				res = result{err: fmt.Errorf("timed out"), duration: c.opts.Timeout}
+			}
+		}
+		for _, metric := range res.metrics {
+			ch <- metric
+		}
+		failed := 0.0
+		if res.err != nil {
+			failed = 1
+		}
+		ch <- c.gauge("scrape_error", failed, g.name)
+		ch <- c.gauge("scrape_duration_seconds", res.duration.Seconds(), g.name)
+	}
+}
+
+func (c *Collector) collectRequests(ctx context.Context) ([]prometheus.Metric, error) {
+	counts, err := c.client.GetRequestCountsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return []prometheus.Metric{
+		c.gauge("requests", float64(counts.Pending), "pending"),
+		c.gauge("requests", float64(counts.Approved), "approved"),
+		c.gauge("requests", float64(counts.Processing), "processing"),
+		c.gauge("requests", float64(counts.Available), "available"),
+	}, nil
+}
+
+func (c *Collector) collectAbout(ctx context.Context) ([]prometheus.Metric, error) {
+	about, err := c.client.GetAboutContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return []prometheus.Metric{
+		c.gauge("info", 1, about.Version, about.TimeZone),
+		c.gauge("requests_total", float64(about.TotalRequests)),
+		c.gauge("media_items_total", float64(about.TotalMediaItems)),
+	}, nil
+}
+
+func (c *Collector) collectCache(ctx context.Context) ([]prometheus.Metric, error) {
+	settings, err := c.client.GetCacheSettingsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var metrics []prometheus.Metric
+	for _, cache := range settings.APICaches {
+		id := string(cache.ID)
+		metrics = append(metrics,
+			c.gauge("cache_hits", float64(cache.Stats.Hits), id),
+			c.gauge("cache_misses", float64(cache.Stats.Misses), id),
+			c.gauge("cache_keys", float64(cache.Stats.Keys), id),
+			c.gauge("cache_key_size_bytes", float64(cache.Stats.KSize), id),
+			c.gauge("cache_value_size_bytes", float64(cache.Stats.VSize), id),
+		)
+	}
+	for source, stats := range settings.ImageCache {
+		metrics = append(metrics,
+			c.gauge("image_cache_size_bytes", float64(stats.Size), source),
+			c.gauge("image_cache_images", float64(stats.ImageCount), source),
+		)
+	}
+	return metrics, nil
+}
+
+func (c *Collector) collectJobs(ctx context.Context) ([]prometheus.Metric, error) {
+	jobs, err := c.client.GetJobsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var metrics []prometheus.Metric
+	for _, job := range jobs {
+		running := 0.0
+		if job.Running {
+			running = 1
+		}
+		metrics = append(metrics, c.gauge("job_running", running, string(job.ID), job.Name))
+		// jobs that are not scheduled have no next run time
+		if !job.NextRunTime.IsZero() {
+			metrics = append(metrics, c.gauge("job_next_run_timestamp_seconds", float64(job.NextRunTime.Unix()), string(job.ID), job.Name))
+		}
+	}
+	return metrics, nil
+}
+
+func (c *Collector) collectPlexSync(ctx context.Context) ([]prometheus.Metric, error) {
+	status, err := c.client.GetPlexSyncStatusContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	running := 0.0
+	if status.Running {
+		running = 1
+	}
+	return []prometheus.Metric{
+		c.gauge("plex_sync_running", running),
+		c.gauge("plex_sync_progress", float64(status.Progress)),
+		c.gauge("plex_sync_total", float64(status.Total)),
+	}, nil
+}
+
+func (c *Collector) allUsers(ctx context.Context) ([]*goverseerr.User, error) {
+	var users []*goverseerr.User
+	for page := 0; ; page++ {
+		batch, pageInfo, err := c.client.GetAllUsersContext(ctx, usersPageSize, page)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, batch...)
+		if len(batch) == 0 || page+1 >= pageInfo.Pages {
+			return users, nil
+		}
+	}
+}
+
+func (c *Collector) collectUsers(ctx context.Context) ([]prometheus.Metric, error) {
+	users, err := c.allUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var metrics []prometheus.Metric
+	byType := make(map[string]int)
+	for _, user := range users {
+		byType[user.UserType.ToString()]++
+		id := strconv.Itoa(user.ID)
+		metrics = append(metrics, c.gauge("user_requests", float64(user.RequestCount), id))
+		if c.opts.EnableUserInfo {
+			metrics = append(metrics, c.gauge("user_info", 1, id, user.Email))
+		}
+	}
+	for userType, count := range byType {
+		metrics = append(metrics, c.gauge("users", float64(count), userType))
+	}
+	return metrics, nil
+}
+
+// collectQuotas fetches the quota of every user. Users whose quota can not
+// be fetched are skipped, the others are still reported along with the
+// error.
+func (c *Collector) collectQuotas(ctx context.Context) ([]prometheus.Metric, error) {
+	users, err := c.allUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var metrics []prometheus.Metric
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var failed []int
+	sem := make(chan struct{}, 4)
+	for _, user := range users {
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(user *goverseerr.User) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			quota, err := c.client.GetUserQuotaContext(ctx, user.ID)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed = append(failed, user.ID)
+				return
+			}
+			id := strconv.Itoa(user.ID)
+			for media, q := range map[string]goverseerr.MediaQuota{"movie": quota.MovieQuota, "tv": quota.TVQuota} {
+				metrics = append(metrics,
+					c.gauge("user_quota_limit", float64(q.Limit), id, media),
+					c.gauge("user_quota_used", float64(q.Used), id, media),
+					c.gauge("user_quota_remaining", float64(q.Remaining), id, media),
+				)
+			}
+		}(user)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return metrics, err
+	}
+	if len(failed) > 0 {
+		sort.Ints(failed)
+		return metrics, fmt.Errorf("failed to get the quota of users %v", failed)
+	}
+	return metrics, nil
+}
@@ -0,0 +1,118 @@
+package prometheus
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/willfantom/goverseerr"
+)
+
+func newTestCollector(t *testing.T, handler http.HandlerFunc, opts Options) *Collector {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/auth/me" {
+			json.NewEncoder(w).Encode(map[string]any{"id": 1})
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	client, err := goverseerr.NewKeyAuth(server.URL, nil, "en", "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewCollector(client, opts)
+}
+
+func TestCollectTimeoutOnlyFailsSlowGroups(t *testing.T) {
+	cancelled := make(chan string, 2)
+	c := newTestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/request/count", "/api/v1/settings/about":
+			<-r.Context().Done()
+			cancelled <- r.URL.Path
+		case "/api/v1/settings/jobs":
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"id": "scheduled", "name": "Scheduled", "nextExecutionTime": "2026-01-01T00:00:00Z"},
+				{"id": "manual", "name": "Manual"},
+			})
+		default:
+			json.NewEncoder(w).Encode(map[string]any{})
+		}
+	}, Options{Prefix: "overseerr", Timeout: 100 * time.Millisecond, EnableRequests: true, EnableAbout: true, EnableJobs: true, EnablePlexSync: true})
+
+	want := `
+# HELP overseerr_scrape_error Whether the last scrape of a metric group failed (1) or succeeded (0).
+# TYPE overseerr_scrape_error gauge
+overseerr_scrape_error{collector="about"} 1
+overseerr_scrape_error{collector="jobs"} 0
+overseerr_scrape_error{collector="plex_sync"} 0
+overseerr_scrape_error{collector="requests"} 1
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(want), "overseerr_scrape_error"); err != nil {
+		t.Fatal(err)
+	}
+	for idx := 0; idx < 2; idx++ {
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			t.Fatal("timed out requests were not cancelled")
+		}
+	}
+
+	want = `
+# HELP overseerr_job_next_run_timestamp_seconds Time of a job's next scheduled run.
+# TYPE overseerr_job_next_run_timestamp_seconds gauge
+overseerr_job_next_run_timestamp_seconds{job="scheduled",name="Scheduled"} 1.7672256e+09
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(want), "overseerr_job_next_run_timestamp_seconds"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCollectQuotaFailuresKeepUsers(t *testing.T) {
+	c := newTestCollector(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/user":
+			json.NewEncoder(w).Encode(map[string]any{
+				"pageInfo": map[string]any{"page": 1, "pages": 1, "results": 2},
+				"results": []map[string]any{
+					{"id": 1, "email": "", "userType": 1, "requestCount": 3},
+					{"id": 2, "email": "", "userType": 2, "requestCount": 5},
+				},
+			})
+		case "/api/v1/user/1/quota":
+			json.NewEncoder(w).Encode(map[string]any{"movie": map[string]any{"limit": 10, "used": 4, "remaining": 6}})
+		default:
+			http.Error(w, `{"message":"boom"}`, http.StatusInternalServerError)
+		}
+	}, Options{Prefix: "overseerr", EnableUsers: true, EnableQuotas: true, EnableUserInfo: true})
+
+	want := `
+# HELP overseerr_scrape_error Whether the last scrape of a metric group failed (1) or succeeded (0).
+# TYPE overseerr_scrape_error gauge
+overseerr_scrape_error{collector="quotas"} 1
+overseerr_scrape_error{collector="users"} 0
+# HELP overseerr_user_requests Number of requests made by a user.
+# TYPE overseerr_user_requests gauge
+overseerr_user_requests{user="1"} 3
+overseerr_user_requests{user="2"} 5
+# HELP overseerr_user_info Maps a user ID to the user's email.
+# TYPE overseerr_user_info gauge
+overseerr_user_info{email="",user="1"} 1
+overseerr_user_info{email="",user="2"} 1
+# HELP overseerr_user_quota_used A user's used request quota.
+# TYPE overseerr_user_quota_used gauge
+overseerr_user_quota_used{media="movie",user="1"} 4
+overseerr_user_quota_used{media="tv",user="1"} 0
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(want), "overseerr_scrape_error", "overseerr_user_requests", "overseerr_user_info", "overseerr_user_quota_used"); err != nil {
+		t.Fatal(err)
+	}
+}
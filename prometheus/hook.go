@@ -0,0 +1,49 @@
+package prometheus
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/willfantom/goverseerr"
+)
+
+// HistogramHook is a goverseerr.Hook that records the latency of every API
+// call in a histogram labelled by operation, status code and error class.
+// Register it as a prometheus.Collector and add it to a client with AddHook.
+type HistogramHook struct {
+	latency *prometheus.HistogramVec
+}
+
+// NewHistogramHook creates a HistogramHook. Buckets default to
+// prometheus.DefBuckets if nil.
+func NewHistogramHook(prefix string, buckets []float64) *HistogramHook {
+	if buckets == nil {
+		buckets = prometheus.DefBuckets
+	}
+	return &HistogramHook{
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: prefix,
+			Name:      "client_request_duration_seconds",
+			Help:      "Latency of requests made to the Overseerr API.",
+			Buckets:   buckets,
+		}, []string{"operation", "status", "error_class"}),
+	}
+}
+
+func (h *HistogramHook) OperationStarted(ctx context.Context, op goverseerr.OperationInfo) context.Context {
+	return ctx
+}
+
+func (h *HistogramHook) OperationFinished(ctx context.Context, op goverseerr.OperationInfo, result goverseerr.OperationResult) {
+	h.latency.WithLabelValues(op.Name, strconv.Itoa(result.StatusCode), string(result.ErrorClass)).
+		Observe(result.Duration.Seconds())
+}
+
+func (h *HistogramHook) Describe(ch chan<- *prometheus.Desc) {
+	h.latency.Describe(ch)
+}
+
+func (h *HistogramHook) Collect(ch chan<- prometheus.Metric) {
+	h.latency.Collect(ch)
+}
@@ -23,8 +23,7 @@ type RadarrSettings struct {
 
 func (o *Overseerr) GetRadarrSettings() ([]*RadarrSettings, error) {
 	var settings []*RadarrSettings
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("radarr.list").
 		SetResult(&settings).Get("/settings/radarr")
 	if err != nil {
 		return nil, err
@@ -37,8 +36,7 @@ func (o *Overseerr) GetRadarrSettings() ([]*RadarrSettings, error) {
 
 func (o *Overseerr) AddRadarr(settings RadarrSettings) (*RadarrSettings, error) {
 	var settingResponse RadarrSettings
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetResult(&settingResponse).
+	resp, err := o.request("radarr.add").SetResult(&settingResponse).
 		SetBody(settings).Post("/settings/radarr")
 	if err != nil {
 		return nil, err
@@ -50,8 +48,7 @@ func (o *Overseerr) AddRadarr(settings RadarrSettings) (*RadarrSettings, error)
 }
 
 func (o *Overseerr) UpdateRadarrSettings(newSettings RadarrSettings, radarrID int) error {
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("radarrID", fmt.Sprintf("%d", radarrID)).
+	resp, err := o.request("radarr.update").SetPathParam("radarrID", fmt.Sprintf("%d", radarrID)).
 		SetBody(newSettings).Post("/settings/radarr/{radarrID}")
 	if err != nil {
 		return err
@@ -62,8 +59,7 @@ func (o *Overseerr) UpdateRadarrSettings(newSettings RadarrSettings, radarrID in
 	return nil
 }
 func (o *Overseerr) DeleteRadarr(radarrID int) error {
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("radarrID", fmt.Sprintf("%d", radarrID)).
+	resp, err := o.request("radarr.delete").SetPathParam("radarrID", fmt.Sprintf("%d", radarrID)).
 		Delete("/settings/radarr/{radarrID}")
 	if err != nil {
 		return err
@@ -75,8 +71,7 @@ func (o *Overseerr) DeleteRadarr(radarrID int) error {
 }
 
 func (o *Overseerr) TestRadarr(settings RadarrSettings) error {
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("radarr.test").
 		SetBody(settings).Post("/settings/radarr/test")
 	if err != nil {
 		return err
@@ -89,8 +84,7 @@ func (o *Overseerr) TestRadarr(settings RadarrSettings) error {
 
 func (o *Overseerr) GetAllRadarrProfiles(radarrID int) ([]*ServiceProfile, error) {
 	var profiles []*ServiceProfile
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("radarrID", fmt.Sprintf("%d", radarrID)).
+	resp, err := o.request("radarr.profiles").SetPathParam("radarrID", fmt.Sprintf("%d", radarrID)).
 		SetResult(&profiles).Get("/settings/radarr/{radarrID}/profiles")
 	if err != nil {
 		return nil, err
@@ -0,0 +1,169 @@
+package render
+
+import "strings"
+
+// Catalog maps message keys to a locale's format strings.
+type Catalog map[string]string
+
+const defaultLocale string = "en"
+
+var builtinCatalogs = map[string]Catalog{
+	"en": {
+		"request.status.pending":   "Pending",
+		"request.status.approved":  "Approved",
+		"request.status.declined":  "Declined",
+		"request.status.available": "Available",
+		"request.status.unknown":   "Unknown",
+		"media.status.unknown":     "Unknown",
+		"media.status.pending":     "Pending",
+		"media.status.processing":  "Processing",
+		"media.status.partial":     "Partially Available",
+		"media.status.available":   "Available",
+		"issue.status.open":        "Open",
+		"issue.status.resolved":    "Resolved",
+		"issue.status.unknown":     "Unknown",
+		"issue.type.video":         "Video",
+		"issue.type.audio":         "Audio",
+		"issue.type.subtitles":     "Subtitles",
+		"issue.type.other":         "Other",
+		"issue.type.unknown":       "Unknown",
+		"media.type.movie":         "Movie",
+		"media.type.tv":            "TV Show",
+		"media.type.person":        "Person",
+		"media.type.unknown":       "Unknown",
+		"time.now":                 "just now",
+		"time.ago":                 "%s ago",
+		"time.in":                  "in %s",
+		"time.second":              "%d second",
+		"time.seconds":             "%d seconds",
+		"time.minute":              "%d minute",
+		"time.minutes":             "%d minutes",
+		"time.hour":                "%d hour",
+		"time.hours":               "%d hours",
+		"time.day":                 "%d day",
+		"time.days":                "%d days",
+	},
+	"de": {
+		"request.status.pending":   "Ausstehend",
+		"request.status.approved":  "Genehmigt",
+		"request.status.declined":  "Abgelehnt",
+		"request.status.available": "Verfügbar",
+		"request.status.unknown":   "Unbekannt",
+		"media.status.unknown":     "Unbekannt",
+		"media.status.pending":     "Ausstehend",
+		"media.status.processing":  "Wird bearbeitet",
+		"media.status.partial":     "Teilweise verfügbar",
+		"media.status.available":   "Verfügbar",
+		"issue.status.open":        "Offen",
+		"issue.status.resolved":    "Gelöst",
+		"issue.status.unknown":     "Unbekannt",
+		"issue.type.video":         "Video",
+		"issue.type.audio":         "Audio",
+		"issue.type.subtitles":     "Untertitel",
+		"issue.type.other":         "Sonstiges",
+		"issue.type.unknown":       "Unbekannt",
+		"media.type.movie":         "Film",
+		"media.type.tv":            "Serie",
+		"media.type.person":        "Person",
+		"media.type.unknown":       "Unbekannt",
+		"time.now":                 "gerade eben",
+		"time.ago":                 "vor %s",
+		"time.in":                  "in %s",
+		"time.second":              "%d Sekunde",
+		"time.seconds":             "%d Sekunden",
+		"time.minute":              "%d Minute",
+		"time.minutes":             "%d Minuten",
+		"time.hour":                "%d Stunde",
+		"time.hours":               "%d Stunden",
+		"time.day":                 "%d Tag",
+		"time.days":                "%d Tagen",
+	},
+	"fr": {
+		"request.status.pending":   "En attente",
+		"request.status.approved":  "Approuvée",
+		"request.status.declined":  "Refusée",
+		"request.status.available": "Disponible",
+		"request.status.unknown":   "Inconnu",
+		"media.status.unknown":     "Inconnu",
+		"media.status.pending":     "En attente",
+		"media.status.processing":  "En traitement",
+		"media.status.partial":     "Partiellement disponible",
+		"media.status.available":   "Disponible",
+		"issue.status.open":        "Ouvert",
+		"issue.status.resolved":    "Résolu",
+		"issue.status.unknown":     "Inconnu",
+		"issue.type.video":         "Vidéo",
+		"issue.type.audio":         "Audio",
+		"issue.type.subtitles":     "Sous-titres",
+		"issue.type.other":         "Autre",
+		"issue.type.unknown":       "Inconnu",
+		"media.type.movie":         "Film",
+		"media.type.tv":            "Série",
+		"media.type.person":        "Personne",
+		"media.type.unknown":       "Inconnu",
+		"time.now":                 "à l'instant",
+		"time.ago":                 "il y a %s",
+		"time.in":                  "dans %s",
+		"time.second":              "%d seconde",
+		"time.seconds":             "%d secondes",
+		"time.minute":              "%d minute",
+		"time.minutes":             "%d minutes",
+		"time.hour":                "%d heure",
+		"time.hours":               "%d heures",
+		"time.day":                 "%d jour",
+		"time.days":                "%d jours",
+	},
+	"es": {
+		"request.status.pending":   "Pendiente",
+		"request.status.approved":  "Aprobada",
+		"request.status.declined":  "Rechazada",
+		"request.status.available": "Disponible",
+		"request.status.unknown":   "Desconocido",
+		"media.status.unknown":     "Desconocido",
+		"media.status.pending":     "Pendiente",
+		"media.status.processing":  "Procesando",
+		"media.status.partial":     "Parcialmente disponible",
+		"media.status.available":   "Disponible",
+		"issue.status.open":        "Abierto",
+		"issue.status.resolved":    "Resuelto",
+		"issue.status.unknown":     "Desconocido",
+		"issue.type.video":         "Vídeo",
+		"issue.type.audio":         "Audio",
+		"issue.type.subtitles":     "Subtítulos",
+		"issue.type.other":         "Otro",
+		"issue.type.unknown":       "Desconocido",
+		"media.type.movie":         "Película",
+		"media.type.tv":            "Serie",
+		"media.type.person":        "Persona",
+		"media.type.unknown":       "Desconocido",
+		"time.now":                 "ahora mismo",
+		"time.ago":                 "hace %s",
+		"time.in":                  "en %s",
+		"time.second":              "%d segundo",
+		"time.seconds":             "%d segundos",
+		"time.minute":              "%d minuto",
+		"time.minutes":             "%d minutos",
+		"time.hour":                "%d hora",
+		"time.hours":               "%d horas",
+		"time.day":                 "%d día",
+		"time.days":                "%d días",
+	},
+}
+
+// lookup finds a message for the locale, falling back from a regional
+// locale (pt-BR) to its base language (pt) and finally to English.
+func lookup(catalogs map[string]Catalog, locale, key string) (string, bool) {
+	candidates := []string{locale}
+	if base, _, found := strings.Cut(locale, "-"); found {
+		candidates = append(candidates, base)
+	}
+	candidates = append(candidates, defaultLocale)
+	for _, candidate := range candidates {
+		if catalog, ok := catalogs[candidate]; ok {
+			if msg, ok := catalog[key]; ok {
+				return msg, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,275 @@
+// Package render formats Overseerr requests, media and issues through
+// text/template with localised helpers for statuses, media types and times.
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/willfantom/goverseerr"
+)
+
+const DefaultTimeFormat string = "Mon Jan 2 15:04:05 -0700 MST 2006"
+
+const (
+	// RequestTemplate renders a *goverseerr.MediaRequest.
+	RequestTemplate string = `{{ emoji .Status }} {{ mediaType .Media.MediaType }} #{{ .Media.TMDB }} - {{ status .Status }} ({{ status .Media.Status }})
+{{ .Creator.Email }} · {{ relTime .Created }}`
+	// MovieTemplate renders a *goverseerr.MovieDetails.
+	MovieTemplate string = `{{ emoji .MediaInfo.Status }} {{ .Title }}{{ with .ReleaseDate }} ({{ . }}){{ end }}
+{{ status .MediaInfo.Status }}
+{{ .Overview }}{{ with .PosterPath }}
+{{ poster . }}{{ end }}`
+	// TVTemplate renders a *goverseerr.TVDetails.
+	TVTemplate string = `{{ emoji .MediaInfo.Status }} {{ .Name }}{{ with .FirstAired }} ({{ . }}){{ end }}
+{{ status .MediaInfo.Status }}
+{{ .Overview }}`
+	// IssueTemplate renders a *goverseerr.Issue.
+	IssueTemplate string = `{{ emoji .Status }} {{ issueType .Type }} #{{ .ID }} - {{ status .Status }}
+{{ mediaType .Media.MediaType }} #{{ .Media.TMDB }}{{ with .ProblemSeason }} S{{ . }}{{ end }}{{ with .ProblemEpisode }}E{{ . }}{{ end }}
+{{ .Creator.Email }} · {{ relTime .Created }}{{ range .Comments }}
+> {{ .Message }}{{ end }}`
+)
+
+type Options struct {
+	// Locale selects the message catalog, defaults to English.
+	Locale string
+	// Location times are displayed in, defaults to time.Local.
+	Location *time.Location
+	// TimeFormat is the layout used by the time helper.
+	TimeFormat string
+	// Catalogs adds or overrides messages, keyed by locale.
+	Catalogs map[string]Catalog
+	// Now is used for relative times, defaults to time.Now.
+	Now func() time.Time
+}
+
+type Renderer struct {
+	opts     Options
+	catalogs map[string]Catalog
+	tmpl     *template.Template
+}
+
+// New creates a Renderer. The built-in request, movie, tv and issue
+// templates are available under the names "request", "movie", "tv" and
+// "issue".
+func New(opts Options) *Renderer {
+	if opts.Locale == "" {
+		opts.Locale = defaultLocale
+	}
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	if opts.TimeFormat == "" {
+		opts.TimeFormat = DefaultTimeFormat
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	r := &Renderer{
+		opts:     opts,
+		catalogs: make(map[string]Catalog),
+	}
+	for locale, catalog := range builtinCatalogs {
+		r.AddCatalog(locale, catalog)
+	}
+	for locale, catalog := range opts.Catalogs {
+		r.AddCatalog(locale, catalog)
+	}
+	r.tmpl = template.New("").Funcs(r.Funcs())
+	template.Must(r.tmpl.New("request").Parse(RequestTemplate))
+	template.Must(r.tmpl.New("movie").Parse(MovieTemplate))
+	template.Must(r.tmpl.New("tv").Parse(TVTemplate))
+	template.Must(r.tmpl.New("issue").Parse(IssueTemplate))
+	return r
+}
+
+// NewForClient creates a Renderer using the client's locale unless one is
+// given in the options.
+func NewForClient(o *goverseerr.Overseerr, opts Options) *Renderer {
+	if opts.Locale == "" {
+		opts.Locale = o.Locale()
+	}
+	return New(opts)
+}
+
+// AddCatalog merges messages into the catalog for the given locale.
+func (r *Renderer) AddCatalog(locale string, catalog Catalog) {
+	existing, ok := r.catalogs[locale]
+	if !ok {
+		existing = make(Catalog)
+		r.catalogs[locale] = existing
+	}
+	for key, msg := range catalog {
+		existing[key] = msg
+	}
+}
+
+// Parse adds a named template, replacing any existing one with that name.
+func (r *Renderer) Parse(name, text string) error {
+	_, err := r.tmpl.New(name).Parse(text)
+	return err
+}
+
+// Render executes the named template with the given data.
+func (r *Renderer) Render(w io.Writer, name string, data any) error {
+	return r.tmpl.ExecuteTemplate(w, name, data)
+}
+
+// RenderString executes the named template and returns the output.
+func (r *Renderer) RenderString(name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := r.Render(&buf, name, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Funcs returns the helpers available to templates, for use with templates
+// managed outside of the Renderer.
+func (r *Renderer) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"t":         r.T,
+		"status":    r.StatusLabel,
+		"emoji":     Emoji,
+		"mediaType": r.MediaTypeLabel,
+		"issueType": r.IssueTypeLabel,
+		"poster":    PosterURL,
+		"time":      r.FormatTime,
+		"relTime":   r.RelativeTime,
+	}
+}
+
+// T translates a message key, formatting it with any given args. The key
+// itself is returned when no catalog holds it.
+func (r *Renderer) T(key string, args ...any) string {
+	msg, ok := lookup(r.catalogs, r.opts.Locale, key)
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// StatusLabel returns the localised label for a RequestStatus, MediaStatus
+// or IssueStatus.
+func (r *Renderer) StatusLabel(status any) string {
+	switch s := status.(type) {
+	case goverseerr.RequestStatus:
+		return r.T("request.status." + strings.ToLower(s.ToString()))
+	case goverseerr.MediaStatus:
+		switch s {
+		case goverseerr.MediaStatusPending:
+			return r.T("media.status.pending")
+		case goverseerr.MediaStatusProcessing:
+			return r.T("media.status.processing")
+		case goverseerr.MediaStatusPartial:
+			return r.T("media.status.partial")
+		case goverseerr.MediaStatusAvailable:
+			return r.T("media.status.available")
+		default:
+			return r.T("media.status.unknown")
+		}
+	case goverseerr.IssueStatus:
+		switch s {
+		case goverseerr.IssueStatusOpen:
+			return r.T("issue.status.open")
+		case goverseerr.IssueStatusResolved:
+			return r.T("issue.status.resolved")
+		default:
+			return r.T("issue.status.unknown")
+		}
+	default:
+		return fmt.Sprint(status)
+	}
+}
+
+// MediaTypeLabel returns the localised label for a MediaType.
+func (r *Renderer) MediaTypeLabel(mediaType goverseerr.MediaType) string {
+	switch mediaType {
+	case goverseerr.MediaTypeMovie, goverseerr.MediaTypeTV, goverseerr.MediaTypePerson:
+		return r.T("media.type." + string(mediaType))
+	default:
+		return r.T("media.type.unknown")
+	}
+}
+
+// IssueTypeLabel returns the localised label for an IssueType.
+func (r *Renderer) IssueTypeLabel(issueType goverseerr.IssueType) string {
+	switch issueType {
+	case goverseerr.IssueTypeVideo, goverseerr.IssueTypeAudio, goverseerr.IssueTypeSubtitles, goverseerr.IssueTypeOther:
+		return r.T("issue.type." + strings.ToLower(issueType.ToString()))
+	default:
+		return r.T("issue.type.unknown")
+	}
+}
+
+// FormatTime formats a time in the configured location and layout.
+func (r *Renderer) FormatTime(t time.Time) string {
+	return t.In(r.opts.Location).Format(r.opts.TimeFormat)
+}
+
+// RelativeTime describes a time relative to now, such as "3 hours ago".
+func (r *Renderer) RelativeTime(t time.Time) string {
+	diff := r.opts.Now().Sub(t)
+	future := diff < 0
+	diff = time.Duration(math.Abs(float64(diff)))
+	var amount string
+	switch {
+	case diff < 10*time.Second:
+		return r.T("time.now")
+	case diff < time.Minute:
+		amount = r.plural("time.second", int(diff/time.Second))
+	case diff < time.Hour:
+		amount = r.plural("time.minute", int(diff/time.Minute))
+	case diff < 24*time.Hour:
+		amount = r.plural("time.hour", int(diff/time.Hour))
+	default:
+		amount = r.plural("time.day", int(diff/(24*time.Hour)))
+	}
+	if future {
+		return r.T("time.in", amount)
+	}
+	return r.T("time.ago", amount)
+}
+
+func (r *Renderer) plural(key string, n int) string {
+	if n == 1 {
+		return r.T(key, n)
+	}
+	return r.T(key+"s", n)
+}
+
+// Emoji returns the emoji for a RequestStatus, MediaStatus, MediaType,
+// IssueStatus or IssueType.
+func Emoji(value any) string {
+	switch v := value.(type) {
+	case goverseerr.RequestStatus:
+		return v.ToEmoji()
+	case goverseerr.MediaStatus:
+		return v.ToEmoji()
+	case goverseerr.MediaType:
+		return v.ToEmoji()
+	case goverseerr.IssueStatus:
+		return v.ToEmoji()
+	case goverseerr.IssueType:
+		return v.ToEmoji()
+	default:
+		return "❓"
+	}
+}
+
+// PosterURL returns the full TMDB image URL for a poster path.
+func PosterURL(path string) string {
+	if path == "" {
+		return ""
+	}
+	return goverseerr.PosterPathBase + path
+}
@@ -0,0 +1,186 @@
+package render
+
+import (
+	"testing"
+	"time"
+
+	"github.com/willfantom/goverseerr"
+)
+
+var testNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestRenderer(opts Options) *Renderer {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	opts.Now = func() time.Time { return testNow }
+	return New(opts)
+}
+
+func TestHelpers(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		data any
+		want string
+	}{
+		{name: "t", tmpl: `{{ t "media.type.movie" }}`, want: "Movie"},
+		{name: "t missing key", tmpl: `{{ t "no.such.key" }}`, want: "no.such.key"},
+		{name: "t args", tmpl: `{{ t "time.ago" "5 minutes" }}`, want: "5 minutes ago"},
+		{name: "request status", tmpl: `{{ status . }}`, data: goverseerr.RequestStatusApproved, want: "Approved"},
+		{name: "media status", tmpl: `{{ status . }}`, data: goverseerr.MediaStatusPartial, want: "Partially Available"},
+		{name: "issue status", tmpl: `{{ status . }}`, data: goverseerr.IssueStatusResolved, want: "Resolved"},
+		{name: "unknown issue status", tmpl: `{{ status . }}`, data: goverseerr.IssueStatus(9), want: "Unknown"},
+		{name: "other status", tmpl: `{{ status . }}`, data: 7, want: "7"},
+		{name: "request emoji", tmpl: `{{ emoji . }}`, data: goverseerr.RequestStatusDeclined, want: "❌"},
+		{name: "media emoji", tmpl: `{{ emoji . }}`, data: goverseerr.MediaStatusAvailable, want: "✅"},
+		{name: "issue type emoji", tmpl: `{{ emoji . }}`, data: goverseerr.IssueTypeSubtitles, want: "💬"},
+		{name: "other emoji", tmpl: `{{ emoji . }}`, data: "x", want: "❓"},
+		{name: "media type", tmpl: `{{ mediaType . }}`, data: goverseerr.MediaTypeTV, want: "TV Show"},
+		{name: "unknown media type", tmpl: `{{ mediaType . }}`, data: goverseerr.MediaType("x"), want: "Unknown"},
+		{name: "issue type", tmpl: `{{ issueType . }}`, data: goverseerr.IssueTypeAudio, want: "Audio"},
+		{name: "poster", tmpl: `{{ poster . }}`, data: "/a.jpg", want: goverseerr.PosterPathBase + "/a.jpg"},
+		{name: "no poster", tmpl: `{{ poster . }}`, data: "", want: ""},
+		{name: "time", tmpl: `{{ time . }}`, data: testNow, want: "Sun Mar 1 12:00:00 +0000 UTC 2026"},
+		{name: "now", tmpl: `{{ relTime . }}`, data: testNow.Add(-5 * time.Second), want: "just now"},
+		{name: "seconds ago", tmpl: `{{ relTime . }}`, data: testNow.Add(-30 * time.Second), want: "30 seconds ago"},
+		{name: "minute ago", tmpl: `{{ relTime . }}`, data: testNow.Add(-time.Minute), want: "1 minute ago"},
+		{name: "in hours", tmpl: `{{ relTime . }}`, data: testNow.Add(2 * time.Hour), want: "in 2 hours"},
+		{name: "days ago", tmpl: `{{ relTime . }}`, data: testNow.Add(-72 * time.Hour), want: "3 days ago"},
+	}
+	r := newTestRenderer(Options{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := r.Parse(tt.name, tt.tmpl); err != nil {
+				t.Fatal(err)
+			}
+			got, err := r.RenderString(tt.name, tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocaleFallback(t *testing.T) {
+	tests := []struct {
+		name     string
+		locale   string
+		catalogs map[string]Catalog
+		key      string
+		want     string
+	}{
+		{name: "builtin", locale: "de", key: "media.status.available", want: "Verfügbar"},
+		{name: "regional to base", locale: "de-AT", key: "media.status.available", want: "Verfügbar"},
+		{name: "unknown to english", locale: "pt-BR", key: "media.status.available", want: "Available"},
+		{name: "missing key to english", locale: "fr", key: "extra.key", catalogs: map[string]Catalog{"en": {"extra.key": "Extra"}}, want: "Extra"},
+		{name: "custom catalog", locale: "pt-BR", key: "media.status.available", catalogs: map[string]Catalog{"pt": {"media.status.available": "Disponível"}}, want: "Disponível"},
+		{name: "regional override", locale: "fr-CA", key: "issue.type.other", catalogs: map[string]Catalog{"fr-CA": {"issue.type.other": "Autres"}}, want: "Autres"},
+		{name: "relative time", locale: "es", key: "time.ago", want: "hace %s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRenderer(Options{Locale: tt.locale, Catalogs: tt.catalogs})
+			if got := r.T(tt.key); got != tt.want {
+				t.Errorf("T(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	tests := []struct {
+		name     string
+		location *time.Location
+		format   string
+		want     string
+	}{
+		{name: "default format", location: time.UTC, want: "Sun Mar 1 12:00:00 +0000 UTC 2026"},
+		{name: "time zone", location: newYork, want: "Sun Mar 1 07:00:00 -0500 EST 2026"},
+		{name: "custom format", location: newYork, format: "2006-01-02 15:04 MST", want: "2026-03-01 07:00 EST"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRenderer(Options{Location: tt.location, TimeFormat: tt.format})
+			if got := r.FormatTime(testNow); got != tt.want {
+				t.Errorf("FormatTime() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuiltinTemplates(t *testing.T) {
+	creator := goverseerr.User{Email: "user@example.com"}
+	tests := []struct {
+		name   string
+		locale string
+		data   any
+		want   string
+	}{
+		{
+			name: "request",
+			data: &goverseerr.MediaRequest{
+				Status:  goverseerr.RequestStatusPending,
+				Media:   goverseerr.MediaInfo{MediaType: goverseerr.MediaTypeMovie, TMDB: 129, Status: goverseerr.MediaStatusPending},
+				Creator: creator,
+				Created: testNow.Add(-2 * time.Hour),
+			},
+			want: "⏱ Movie #129 - Pending (Pending)\nuser@example.com · 2 hours ago",
+		},
+		{
+			name:   "movie",
+			locale: "fr",
+			data: &goverseerr.MovieDetails{
+				Title:       "Spirited Away",
+				ReleaseDate: "2001-07-20",
+				Overview:    "A girl wanders into a world of spirits.",
+				MediaInfo:   goverseerr.MediaInfo{Status: goverseerr.MediaStatusAvailable},
+			},
+			want: "✅ Spirited Away (2001-07-20)\nDisponible\nA girl wanders into a world of spirits.",
+		},
+		{
+			name: "tv",
+			data: &goverseerr.TVDetails{
+				Name:      "Breaking Bad",
+				Overview:  "A teacher turns to crime.",
+				MediaInfo: goverseerr.MediaInfo{Status: goverseerr.MediaStatusPartial},
+			},
+			want: "✔️ Breaking Bad\nPartially Available\nA teacher turns to crime.",
+		},
+		{
+			name:   "issue",
+			locale: "de",
+			data: &goverseerr.Issue{
+				ID:             12,
+				Type:           goverseerr.IssueTypeSubtitles,
+				Status:         goverseerr.IssueStatusOpen,
+				ProblemSeason:  2,
+				ProblemEpisode: 5,
+				Media:          goverseerr.MediaInfo{MediaType: goverseerr.MediaTypeTV, TMDB: 1396},
+				Creator:        creator,
+				Created:        testNow.Add(-24 * time.Hour),
+				Comments:       []goverseerr.IssueComment{{Message: "Subtitles are out of sync."}},
+			},
+			want: "⚠️ Untertitel #12 - Offen\nSerie #1396 S2E5\nuser@example.com · vor 1 Tag\n> Subtitles are out of sync.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRenderer(Options{Locale: tt.locale})
+			got, err := r.RenderString(tt.name, tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package goverseerr
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -89,9 +90,13 @@ const (
 )
 
 func (o *Overseerr) GetRequests(pageNumber, pageSize int, filter RequestFilter, sort RequestSort) ([]*MediaRequest, *Page, error) {
+	return o.GetRequestsContext(context.Background(), pageNumber, pageSize, filter, sort)
+}
+
+// GetRequestsContext is GetRequests with a context for the request.
+func (o *Overseerr) GetRequestsContext(ctx context.Context, pageNumber, pageSize int, filter RequestFilter, sort RequestSort) ([]*MediaRequest, *Page, error) {
 	var requests MediaRequestResponse
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetQueryParams(map[string]string{
+	resp, err := o.requestContext(ctx, "requests.list").SetQueryParams(map[string]string{
 		"take":   fmt.Sprintf("%d", pageSize),
 		"skip":   fmt.Sprintf("%d", pageSize*pageNumber),
 		"filter": string(filter),
@@ -107,9 +112,13 @@ func (o *Overseerr) GetRequests(pageNumber, pageSize int, filter RequestFilter,
 }
 
 func (o *Overseerr) GetRequestCounts() (*RequestCounts, error) {
+	return o.GetRequestCountsContext(context.Background())
+}
+
+// GetRequestCountsContext is GetRequestCounts with a context for the request.
+func (o *Overseerr) GetRequestCountsContext(ctx context.Context) (*RequestCounts, error) {
 	var counts RequestCounts
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetResult(&counts).Get("/request/count")
+	resp, err := o.requestContext(ctx, "requests.count").SetResult(&counts).Get("/request/count")
 	if err != nil {
 		return nil, err
 	}
@@ -120,9 +129,14 @@ func (o *Overseerr) GetRequestCounts() (*RequestCounts, error) {
 }
 
 func (o *Overseerr) GetRequestsByUser(pageNumber, pageSize, userID int, filter RequestFilter, sort RequestSort) ([]*MediaRequest, *Page, error) {
+	return o.GetRequestsByUserContext(context.Background(), pageNumber, pageSize, userID, filter, sort)
+}
+
+// GetRequestsByUserContext is GetRequestsByUser with a context for the
+// request.
+func (o *Overseerr) GetRequestsByUserContext(ctx context.Context, pageNumber, pageSize, userID int, filter RequestFilter, sort RequestSort) ([]*MediaRequest, *Page, error) {
 	var requests MediaRequestResponse
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetQueryParams(map[string]string{
+	resp, err := o.requestContext(ctx, "requests.by_user").SetQueryParams(map[string]string{
 		"take":        fmt.Sprintf("%d", pageSize),
 		"skip":        fmt.Sprintf("%d", pageSize*pageNumber),
 		"filter":      string(filter),
@@ -140,8 +154,7 @@ func (o *Overseerr) GetRequestsByUser(pageNumber, pageSize, userID int, filter R
 
 func (o *Overseerr) CreateRequest(request NewRequest) (*MediaRequest, error) {
 	var requestConfirmed MediaRequest
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetBody(request).
+	resp, err := o.request("requests.create").SetBody(request).
 		SetResult(&requestConfirmed).Post("/request")
 	if err != nil {
 		return nil, err
@@ -154,8 +167,7 @@ func (o *Overseerr) CreateRequest(request NewRequest) (*MediaRequest, error) {
 
 func (o *Overseerr) UpdateRequest(requestID int, request MediaRequest) (*MediaRequest, error) {
 	var requestConfirmed MediaRequest
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetBody(request).
+	resp, err := o.request("requests.update").SetBody(request).
 		SetPathParam("requestID", fmt.Sprintf("%d", requestID)).
 		SetResult(&requestConfirmed).Put("/request/{requestID}")
 	if err != nil {
@@ -169,8 +181,7 @@ func (o *Overseerr) UpdateRequest(requestID int, request MediaRequest) (*MediaRe
 
 func (o *Overseerr) RetryRequest(requestID int) (*MediaRequest, error) {
 	var requestConfirmed MediaRequest
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("requests.retry").
 		SetPathParam("requestID", fmt.Sprintf("%d", requestID)).
 		SetResult(&requestConfirmed).Post("/request/{requestID}/retry")
 	if err != nil {
@@ -184,8 +195,7 @@ func (o *Overseerr) RetryRequest(requestID int) (*MediaRequest, error) {
 
 func (o *Overseerr) setRequestStatus(requestID int, status string) (*MediaRequest, error) {
 	var requestConfirmed MediaRequest
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParams(map[string]string{
+	resp, err := o.request("requests." + status).SetPathParams(map[string]string{
 		"requestID": fmt.Sprintf("%d", requestID),
 		"status":    status,
 	}).
@@ -209,8 +219,7 @@ func (o *Overseerr) DeclineRequest(requestID int) (*MediaRequest, error) {
 
 func (o *Overseerr) GetRequest(requestID int) (*MediaRequest, error) {
 	var request MediaRequest
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParams(map[string]string{
+	resp, err := o.request("requests.get").SetPathParams(map[string]string{
 		"requestID": fmt.Sprintf("%d", requestID),
 	}).
 		SetResult(&request).Get("/request/{requestID}")
@@ -224,8 +233,7 @@ func (o *Overseerr) GetRequest(requestID int) (*MediaRequest, error) {
 }
 
 func (o *Overseerr) DeleteRequest(requestID int) error {
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("requestID", fmt.Sprintf("%d", requestID)).
+	resp, err := o.request("requests.delete").SetPathParam("requestID", fmt.Sprintf("%d", requestID)).
 		Delete("/request/{requestID}")
 	if err != nil {
 		return err
@@ -237,16 +245,7 @@ func (o *Overseerr) DeleteRequest(requestID int) error {
 }
 
 func (req MediaRequest) ToFriendly(o *Overseerr) (*FriendlyMediaRequest, error) {
-	var friendly FriendlyMediaRequest
-	friendly.ID = req.ID
-	friendly.Status = req.Status.ToString()
-	friendly.StatusEmoji = req.Status.ToEmoji()
-	friendly.MediaType = string(req.Media.MediaType)
-	friendly.MediaTypeEmoji = req.Media.MediaType.ToEmoji()
-	friendly.CreatorEmail = req.Creator.Email
-	friendly.CreatedDate = req.Created.Local().Format("Mon Jan 2 15:04:05 -0700 MST 2006")
-	friendly.MediaStatus = req.Media.Status.ToString()
-	friendly.MediaStatusEmoji = req.Media.Status.ToEmoji()
+	friendly := req.friendlyBase()
 	if req.Media.MediaType == MediaTypeMovie {
 		movie, err := req.GetMovieDetails(o)
 		if err != nil {
@@ -263,7 +262,21 @@ func (req MediaRequest) ToFriendly(o *Overseerr) (*FriendlyMediaRequest, error)
 		friendly.ContentTitle = tv.Name
 		friendly.ContentDate = tv.FirstAired
 	}
-	return &friendly, nil
+	return friendly, nil
+}
+
+func (req MediaRequest) friendlyBase() *FriendlyMediaRequest {
+	var friendly FriendlyMediaRequest
+	friendly.ID = req.ID
+	friendly.Status = req.Status.ToString()
+	friendly.StatusEmoji = req.Status.ToEmoji()
+	friendly.MediaType = string(req.Media.MediaType)
+	friendly.MediaTypeEmoji = req.Media.MediaType.ToEmoji()
+	friendly.CreatorEmail = req.Creator.Email
+	friendly.CreatedDate = req.Created.Local().Format("Mon Jan 2 15:04:05 -0700 MST 2006")
+	friendly.MediaStatus = req.Media.Status.ToString()
+	friendly.MediaStatusEmoji = req.Media.Status.ToEmoji()
+	return &friendly
 }
 
 func (req MediaRequest) GetTVDetails(o *Overseerr) (*TVDetails, error) {
@@ -82,8 +82,7 @@ func (o *Overseerr) Search(query string, pageNumber int) (*SearchResults, error)
 		return nil, fmt.Errorf("page number must be 1 or higher")
 	}
 	var results SearchResults
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetQueryParams(map[string]string{
+	resp, err := o.request("search").SetQueryParams(map[string]string{
 		"query":    url.QueryEscape(query),
 		"page":     fmt.Sprintf("%d", pageNumber),
 		"language": o.locale,
@@ -101,8 +100,7 @@ func (o *Overseerr) Search(query string, pageNumber int) (*SearchResults, error)
 
 func (o *Overseerr) MovieGenres() ([]*Genre, error) {
 	var genres []*Genre
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetQueryParams(map[string]string{
+	resp, err := o.request("genres.movie").SetQueryParams(map[string]string{
 		"language": o.locale,
 	}).SetResult(&genres).Get("/discover/genreslider/movie")
 	if err != nil {
@@ -116,8 +114,7 @@ func (o *Overseerr) MovieGenres() ([]*Genre, error) {
 
 func (o *Overseerr) TVGenres() ([]*Genre, error) {
 	var genres []*Genre
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetQueryParams(map[string]string{
+	resp, err := o.request("genres.tv").SetQueryParams(map[string]string{
 		"language": o.locale,
 	}).SetResult(&genres).Get("/discover/genreslider/tv")
 	if err != nil {
@@ -34,8 +34,7 @@ type RootFolder struct {
 
 func (o *Overseerr) GetRadarrServers() ([]*RadarrSettings, error) {
 	var settings []*RadarrSettings
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("service.radarr.list").
 		SetResult(&settings).Get("/service/radarr")
 	if err != nil {
 		return nil, err
@@ -48,8 +47,7 @@ func (o *Overseerr) GetRadarrServers() ([]*RadarrSettings, error) {
 
 func (o *Overseerr) GetRadarrProfiles(radarrID int) (*RadarrService, error) {
 	var services RadarrService
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("radarrID", fmt.Sprintf("%d", radarrID)).
+	resp, err := o.request("service.radarr.get").SetPathParam("radarrID", fmt.Sprintf("%d", radarrID)).
 		SetResult(&services).Get("/service/radarr/{radarrID}")
 	if err != nil {
 		return nil, err
@@ -62,8 +60,7 @@ func (o *Overseerr) GetRadarrProfiles(radarrID int) (*RadarrService, error) {
 
 func (o *Overseerr) GetSonarrServers() ([]*SonarrSettings, error) {
 	var settings []*SonarrSettings
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("service.sonarr.list").
 		SetResult(&settings).Get("/service/sonarr")
 	if err != nil {
 		return nil, err
@@ -76,8 +73,7 @@ func (o *Overseerr) GetSonarrServers() ([]*SonarrSettings, error) {
 
 func (o *Overseerr) GetSonarrProfiles(sonarrID int) (*SonarrService, error) {
 	var services SonarrService
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("sonarrID", fmt.Sprintf("%d", sonarrID)).
+	resp, err := o.request("service.sonarr.get").SetPathParam("sonarrID", fmt.Sprintf("%d", sonarrID)).
 		SetResult(&services).Get("/service/sonarr/{sonarrID}")
 	if err != nil {
 		return nil, err
@@ -1,19 +1,105 @@
 package goverseerr
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 )
 
+// MainSettings holds the general settings of an Overseerr instance. Fields
+// returned by the server that are not modelled here are kept in Extra and
+// sent back on update, so round trips never drop configuration.
+// HideAvailable4K is nil when the server does not report it, so it is only
+// sent back to servers that support hiding available 4K media separately.
 type MainSettings struct {
-	APIKey                 string `json:"apiKey"`
-	AppTitle               string `json:"applicationTitle"`
-	AppURL                 string `json:"applicationUrl"`
-	TrustProxy             bool   `json:"trustProxy"`
-	CSRFProtection         bool   `json:"csrfProtection"`
-	HideAvailable          bool   `json:"hideAvailable"`
-	PartialRequestsEnabled bool   `json:"partialRequestsEnabled"`
-	LocalLogin             bool   `json:"localLogin"`
-	DefaultPermissions     int    `json:"defaultPermissions"`
+	APIKey                 string                     `json:"apiKey"`
+	AppTitle               string                     `json:"applicationTitle"`
+	AppURL                 string                     `json:"applicationUrl"`
+	TrustProxy             bool                       `json:"trustProxy"`
+	CSRFProtection         bool                       `json:"csrfProtection"`
+	CacheImages            bool                       `json:"cacheImages"`
+	DefaultPermissions     int                        `json:"defaultPermissions"`
+	DefaultQuotas          DefaultQuotas              `json:"defaultQuotas"`
+	HideAvailable          bool                       `json:"hideAvailable"`
+	HideAvailable4K        *bool                      `json:"hideAvailable4k,omitempty"`
+	LocalLogin             bool                       `json:"localLogin"`
+	NewPlexLogin           bool                       `json:"newPlexLogin"`
+	Region                 string                     `json:"region"`
+	OriginalLanguage       string                     `json:"originalLanguage"`
+	Locale                 string                     `json:"locale"`
+	MediaServerType        MediaServerType            `json:"mediaServerType"`
+	PartialRequestsEnabled bool                       `json:"partialRequestsEnabled"`
+	EnableSpecialEpisodes  bool                       `json:"enableSpecialEpisodes"`
+	Extra                  map[string]json.RawMessage `json:"-"`
+}
+
+type DefaultQuotas struct {
+	Movie Quota `json:"movie"`
+	TV    Quota `json:"tv"`
+}
+
+type Quota struct {
+	Limit int `json:"quotaLimit"`
+	Days  int `json:"quotaDays"`
+}
+
+type MediaServerType int
+
+const (
+	MediaServerTypePlex     MediaServerType = 1
+	MediaServerTypeJellyfin MediaServerType = 2
+	MediaServerTypeEmby     MediaServerType = 3
+)
+
+// NetworkSettings holds the network section of an Overseerr instance's
+// settings. Unmodelled fields are preserved in Extra as with MainSettings.
+type NetworkSettings struct {
+	CSRFProtection bool                       `json:"csrfProtection"`
+	TrustProxy     bool                       `json:"trustProxy"`
+	ForceIPv4First bool                       `json:"forceIpv4First"`
+	Proxy          ProxySettings              `json:"proxy"`
+	DNSCache       DNSCacheSettings           `json:"dnsCache"`
+	Extra          map[string]json.RawMessage `json:"-"`
+}
+
+type ProxySettings struct {
+	Enabled              bool   `json:"enabled"`
+	Hostname             string `json:"hostname"`
+	Port                 int    `json:"port"`
+	UseSSL               bool   `json:"useSsl"`
+	User                 string `json:"user"`
+	Password             string `json:"password"`
+	BypassFilter         string `json:"bypassFilter"`
+	BypassLocalAddresses bool   `json:"bypassLocalAddresses"`
+}
+
+type DNSCacheSettings struct {
+	Enabled     bool `json:"enabled"`
+	ForceMinTTL int  `json:"forceMinTtl"`
+	ForceMaxTTL int  `json:"forceMaxTtl"`
+}
+
+type mainSettingsFields MainSettings
+type networkSettingsFields NetworkSettings
+
+func (s *MainSettings) UnmarshalJSON(data []byte) error {
+	extra, err := unmarshalKeepingExtra(data, (*mainSettingsFields)(s))
+	s.Extra = extra
+	return err
+}
+
+func (s MainSettings) MarshalJSON() ([]byte, error) {
+	return marshalWithExtra(mainSettingsFields(s), s.Extra)
+}
+
+func (s *NetworkSettings) UnmarshalJSON(data []byte) error {
+	extra, err := unmarshalKeepingExtra(data, (*networkSettingsFields)(s))
+	s.Extra = extra
+	return err
+}
+
+func (s NetworkSettings) MarshalJSON() ([]byte, error) {
+	return marshalWithExtra(networkSettingsFields(s), s.Extra)
 }
 
 type PublicSettings struct {
@@ -29,8 +115,7 @@ type About struct {
 
 func (o *Overseerr) GetMainSettings() (*MainSettings, error) {
 	var settings MainSettings
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("settings.main.get").
 		SetResult(&settings).Get("/settings/main")
 	if err != nil {
 		return nil, err
@@ -43,8 +128,7 @@ func (o *Overseerr) GetMainSettings() (*MainSettings, error) {
 
 func (o *Overseerr) UpdateMainSettings(newSettings MainSettings) (*MainSettings, error) {
 	var settings MainSettings
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("settings.main.update").
 		SetBody(newSettings).SetResult(&settings).Post("/settings/main")
 	if err != nil {
 		return nil, err
@@ -55,11 +139,18 @@ func (o *Overseerr) UpdateMainSettings(newSettings MainSettings) (*MainSettings,
 	return &settings, nil
 }
 
+// RegenerateMainSettings generates a new API key, invalidating the old one.
+// The client keeps using the old key, see RotateAPIKey.
 func (o *Overseerr) RegenerateMainSettings() (*MainSettings, error) {
+	return o.RegenerateMainSettingsContext(context.Background())
+}
+
+// RegenerateMainSettingsContext is RegenerateMainSettings with a context for
+// the request.
+func (o *Overseerr) RegenerateMainSettingsContext(ctx context.Context) (*MainSettings, error) {
 	var settings MainSettings
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
-		SetResult(&settings).Get("/settings/main/regenerate")
+	resp, err := o.requestContext(ctx, "settings.main.regenerate").
+		SetResult(&settings).Post("/settings/main/regenerate")
 	if err != nil {
 		return nil, err
 	}
@@ -71,8 +162,7 @@ func (o *Overseerr) RegenerateMainSettings() (*MainSettings, error) {
 
 func (o *Overseerr) GetPublicSettings() (*PublicSettings, error) {
 	var settings PublicSettings
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("settings.public").
 		SetResult(&settings).Get("/settings/public")
 	if err != nil {
 		return nil, err
@@ -83,10 +173,28 @@ func (o *Overseerr) GetPublicSettings() (*PublicSettings, error) {
 	return &settings, nil
 }
 
+// InitializeSettings marks first-run setup as complete.
+func (o *Overseerr) InitializeSettings() (*PublicSettings, error) {
+	var settings PublicSettings
+	resp, err := o.request("settings.initialize").
+		SetResult(&settings).Post("/settings/initialize")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("received non-200 status code (%d)", resp.StatusCode())
+	}
+	return &settings, nil
+}
+
 func (o *Overseerr) GetAbout() (*About, error) {
+	return o.GetAboutContext(context.Background())
+}
+
+// GetAboutContext is GetAbout with a context for the request.
+func (o *Overseerr) GetAboutContext(ctx context.Context) (*About, error) {
 	var about About
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.requestContext(ctx, "settings.about").
 		SetResult(&about).Get("/settings/about")
 	if err != nil {
 		return nil, err
@@ -96,3 +204,29 @@ func (o *Overseerr) GetAbout() (*About, error) {
 	}
 	return &about, nil
 }
+
+func (o *Overseerr) GetNetworkSettings() (*NetworkSettings, error) {
+	var settings NetworkSettings
+	resp, err := o.request("settings.network.get").
+		SetResult(&settings).Get("/settings/network")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("received non-200 status code (%d)", resp.StatusCode())
+	}
+	return &settings, nil
+}
+
+func (o *Overseerr) UpdateNetworkSettings(newSettings NetworkSettings) (*NetworkSettings, error) {
+	var settings NetworkSettings
+	resp, err := o.request("settings.network.update").
+		SetBody(newSettings).SetResult(&settings).Post("/settings/network")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("received non-200 status code (%d)", resp.StatusCode())
+	}
+	return &settings, nil
+}
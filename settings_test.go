@@ -0,0 +1,71 @@
+package goverseerr
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMainSettingsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		get  string
+	}{
+		{
+			name: "unknown fields",
+			get: `{"applicationTitle": "Overseerr", "hideAvailable": true, "hideAvailable4k": false, "mediaServerType": 2,
+				"defaultQuotas": {"movie": {"quotaLimit": 5, "quotaDays": 7}, "tv": {"quotaLimit": 0, "quotaDays": 0}},
+				"streamingRegion": "GB", "youtubeUrl": "", "futureSection": {"enabled": true, "values": [1, 2]}}`,
+		},
+		{
+			name: "no 4k option",
+			get:  `{"applicationTitle": "Overseerr", "hideAvailable": true, "mediaServerType": 1}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent map[string]any
+			o := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					body, _ := io.ReadAll(r.Body)
+					if err := json.Unmarshal(body, &sent); err != nil {
+						t.Error(err)
+					}
+					w.Write(body)
+					return
+				}
+				w.Write([]byte(tt.get))
+			})
+			settings, err := o.GetMainSettings()
+			if err != nil {
+				t.Fatal(err)
+			}
+			settings.AppTitle = "Requests"
+			updated, err := o.UpdateMainSettings(*settings)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(updated.Extra) != len(settings.Extra) {
+				t.Errorf("extra after update = %v, want %v", updated.Extra, settings.Extra)
+			}
+
+			var want map[string]any
+			if err := json.Unmarshal([]byte(tt.get), &want); err != nil {
+				t.Fatal(err)
+			}
+			want["applicationTitle"] = "Requests"
+			for key, value := range want {
+				if !reflect.DeepEqual(sent[key], value) {
+					t.Errorf("sent %s = %v, want %v", key, sent[key], value)
+				}
+			}
+			if _, ok := want["hideAvailable4k"]; !ok {
+				if _, sentOK := sent["hideAvailable4k"]; sentOK {
+					t.Error("hideAvailable4k sent to a server that does not report it")
+				}
+			}
+		})
+	}
+}
@@ -28,8 +28,7 @@ type SonarrSettings struct {
 
 func (o *Overseerr) GetSonarrSettings() ([]*SonarrSettings, error) {
 	var settings []*SonarrSettings
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("sonarr.list").
 		SetResult(&settings).Get("/settings/sonarr")
 	if err != nil {
 		return nil, err
@@ -42,8 +41,7 @@ func (o *Overseerr) GetSonarrSettings() ([]*SonarrSettings, error) {
 
 func (o *Overseerr) AddSonarr(settings SonarrSettings) (*SonarrSettings, error) {
 	var settingResponse SonarrSettings
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetResult(&settingResponse).
+	resp, err := o.request("sonarr.add").SetResult(&settingResponse).
 		SetBody(settings).Post("/settings/sonarr")
 	if err != nil {
 		return nil, err
@@ -54,8 +52,7 @@ func (o *Overseerr) AddSonarr(settings SonarrSettings) (*SonarrSettings, error)
 	return &settingResponse, nil
 }
 func (o *Overseerr) UpdateSonarrSettings(newSettings SonarrSettings, sonarrID int) error {
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("sonarrID", fmt.Sprintf("%d", sonarrID)).
+	resp, err := o.request("sonarr.update").SetPathParam("sonarrID", fmt.Sprintf("%d", sonarrID)).
 		SetBody(newSettings).Post("/settings/sonarr/{sonarrID}")
 	if err != nil {
 		return err
@@ -67,8 +64,7 @@ func (o *Overseerr) UpdateSonarrSettings(newSettings SonarrSettings, sonarrID in
 }
 
 func (o *Overseerr) DeleteSonarr(sonarrID int) error {
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("sonarrID", fmt.Sprintf("%d", sonarrID)).
+	resp, err := o.request("sonarr.delete").SetPathParam("sonarrID", fmt.Sprintf("%d", sonarrID)).
 		Delete("/settings/sonarr/{sonarrID}")
 	if err != nil {
 		return err
@@ -80,8 +76,7 @@ func (o *Overseerr) DeleteSonarr(sonarrID int) error {
 }
 
 func (o *Overseerr) TestSonarr(settings SonarrSettings) error {
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("sonarr.test").
 		SetBody(settings).Post("/settings/sonarr/test")
 	if err != nil {
 		return err
@@ -1,6 +1,9 @@
 package goverseerr
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 type TVDetails struct {
 	ID                  int                 `json:"id"`
@@ -75,9 +78,13 @@ type Network struct {
 }
 
 func (o *Overseerr) GetTVDetails(tvID int) (*TVDetails, error) {
+	return o.GetTVDetailsContext(context.Background(), tvID)
+}
+
+// GetTVDetailsContext is GetTVDetails with a context for the request.
+func (o *Overseerr) GetTVDetailsContext(ctx context.Context, tvID int) (*TVDetails, error) {
 	var details TVDetails
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("tvID", fmt.Sprintf("%d", tvID)).
+	resp, err := o.requestContext(ctx, "tv.details").SetPathParam("tvID", fmt.Sprintf("%d", tvID)).
 		SetQueryParam("language", o.locale).SetResult(&details).Get("/tv/{tvID}")
 	if err != nil {
 		return nil, err
@@ -90,8 +97,7 @@ func (o *Overseerr) GetTVDetails(tvID int) (*TVDetails, error) {
 
 func (o *Overseerr) GetTVSeason(tvID, seasonID int) (*Season, error) {
 	var details Season
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParams(map[string]string{
+	resp, err := o.request("tv.season").SetPathParams(map[string]string{
 		"tvID":     fmt.Sprintf("%d", tvID),
 		"seasonID": fmt.Sprintf("%d", seasonID),
 	}).
@@ -110,8 +116,7 @@ func (o *Overseerr) GetTVRecommendations(tvID, page int) (*SearchResults, error)
 		return nil, fmt.Errorf("page number must be 1 or higher")
 	}
 	var results SearchResults
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("tvID", fmt.Sprintf("%d", tvID)).
+	resp, err := o.request("tv.recommendations").SetPathParam("tvID", fmt.Sprintf("%d", tvID)).
 		SetQueryParams(map[string]string{
 			"language": o.locale,
 			"page":     fmt.Sprintf("%d", page),
@@ -130,8 +135,7 @@ func (o *Overseerr) GetTVSimilar(tvID, page int) (*SearchResults, error) {
 		return nil, fmt.Errorf("page number must be 1 or higher")
 	}
 	var results SearchResults
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("tvID", fmt.Sprintf("%d", tvID)).
+	resp, err := o.request("tv.similar").SetPathParam("tvID", fmt.Sprintf("%d", tvID)).
 		SetQueryParams(map[string]string{
 			"language": o.locale,
 			"page":     fmt.Sprintf("%d", page),
@@ -147,8 +151,7 @@ func (o *Overseerr) GetTVSimilar(tvID, page int) (*SearchResults, error) {
 
 func (o *Overseerr) GetTVRatings(tvID int) (*Rating, error) {
 	var rating Rating
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("tvID", fmt.Sprintf("%d", tvID)).
+	resp, err := o.request("tv.ratings").SetPathParam("tvID", fmt.Sprintf("%d", tvID)).
 		SetResult(&rating).Get("/tv/{tvID}/ratings")
 	if err != nil {
 		return nil, err
@@ -1,6 +1,8 @@
 package goverseerr
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -42,12 +44,32 @@ type UserSettings struct {
 	TelegramSilent     bool   `json:"telegramSendSilently"`
 }
 
+// GenerealUserSettings holds a user's general settings. Fields returned by
+// the server that are not modelled here are kept in Extra and sent back on
+// update, as with MainSettings.
 type GenerealUserSettings struct {
-	Username        string `json:"username"`
-	MovieQuotaLimit int    `json:"movieQuotaLimit"`
-	MovieQuotaDays  int    `json:"movieQuotaDays"`
-	TVQuotaLimit    int    `json:"tvQuotaLimit"`
-	TVQuotaDays     int    `json:"tvQuotaDays"`
+	Username         string                     `json:"username"`
+	DiscordID        string                     `json:"discordId,omitempty"`
+	Locale           string                     `json:"locale,omitempty"`
+	Region           string                     `json:"region,omitempty"`
+	OriginalLanguage string                     `json:"originalLanguage,omitempty"`
+	MovieQuotaLimit  int                        `json:"movieQuotaLimit"`
+	MovieQuotaDays   int                        `json:"movieQuotaDays"`
+	TVQuotaLimit     int                        `json:"tvQuotaLimit"`
+	TVQuotaDays      int                        `json:"tvQuotaDays"`
+	Extra            map[string]json.RawMessage `json:"-"`
+}
+
+type generalUserSettingsFields GenerealUserSettings
+
+func (s *GenerealUserSettings) UnmarshalJSON(data []byte) error {
+	extra, err := unmarshalKeepingExtra(data, (*generalUserSettingsFields)(s))
+	s.Extra = extra
+	return err
+}
+
+func (s GenerealUserSettings) MarshalJSON() ([]byte, error) {
+	return marshalWithExtra(generalUserSettingsFields(s), s.Extra)
 }
 
 type UserQuota struct {
@@ -66,9 +88,13 @@ type MediaQuota struct {
 // User
 
 func (o *Overseerr) GetAllUsers(pageSize, pageNumber int) ([]*User, *Page, error) {
+	return o.GetAllUsersContext(context.Background(), pageSize, pageNumber)
+}
+
+// GetAllUsersContext is GetAllUsers with a context for the request.
+func (o *Overseerr) GetAllUsersContext(ctx context.Context, pageSize, pageNumber int) ([]*User, *Page, error) {
 	var usersResponse UsersResponse
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.requestContext(ctx, "users.list").
 		SetQueryParams(map[string]string{
 			"take": fmt.Sprintf("%d", pageSize),
 			"skip": fmt.Sprintf("%d", pageSize*pageNumber),
@@ -85,8 +111,7 @@ func (o *Overseerr) GetAllUsers(pageSize, pageNumber int) ([]*User, *Page, error
 
 func (o *Overseerr) GetUser(userID int) (*User, error) {
 	var user User
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("userID", fmt.Sprintf("%d", userID)).
+	resp, err := o.request("users.get").SetPathParam("userID", fmt.Sprintf("%d", userID)).
 		SetResult(&user).Get("/user/{userID}")
 	if err != nil {
 		return nil, err
@@ -99,8 +124,7 @@ func (o *Overseerr) GetUser(userID int) (*User, error) {
 
 func (o *Overseerr) CreateNewUser(newUser User) (*User, error) {
 	var user User
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetBody(newUser).
+	resp, err := o.request("users.create").SetBody(newUser).
 		SetResult(&user).Post("/user")
 	if err != nil {
 		return nil, err
@@ -113,8 +137,7 @@ func (o *Overseerr) CreateNewUser(newUser User) (*User, error) {
 
 func (o *Overseerr) UpdateUser(userID int, updatedUser User) (*User, error) {
 	var user User
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("userID", fmt.Sprintf("%d", userID)).
+	resp, err := o.request("users.update").SetPathParam("userID", fmt.Sprintf("%d", userID)).
 		SetBody(updatedUser).SetResult(&user).Put("/user/{userID}")
 	if err != nil {
 		return nil, err
@@ -127,8 +150,7 @@ func (o *Overseerr) UpdateUser(userID int, updatedUser User) (*User, error) {
 
 func (o *Overseerr) DeleteUser(userID int) (*User, error) {
 	var user User
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("userID", fmt.Sprintf("%d", userID)).
+	resp, err := o.request("users.delete").SetPathParam("userID", fmt.Sprintf("%d", userID)).
 		SetResult(&user).Delete("/user/{userID}")
 	if err != nil {
 		return nil, err
@@ -140,9 +162,13 @@ func (o *Overseerr) DeleteUser(userID int) (*User, error) {
 }
 
 func (o *Overseerr) GetLoggedInUser() (*User, error) {
+	return o.GetLoggedInUserContext(context.Background())
+}
+
+// GetLoggedInUserContext is GetLoggedInUser with a context for the request.
+func (o *Overseerr) GetLoggedInUserContext(ctx context.Context) (*User, error) {
 	var user User
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.requestContext(ctx, "auth.me").
 		SetResult(&user).Get("/auth/me")
 	if err != nil {
 		return nil, err
@@ -154,9 +180,13 @@ func (o *Overseerr) GetLoggedInUser() (*User, error) {
 }
 
 func (o *Overseerr) GetUserQuota(userID int) (*UserQuota, error) {
+	return o.GetUserQuotaContext(context.Background(), userID)
+}
+
+// GetUserQuotaContext is GetUserQuota with a context for the request.
+func (o *Overseerr) GetUserQuotaContext(ctx context.Context, userID int) (*UserQuota, error) {
 	var quota UserQuota
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("userID", fmt.Sprintf("%d", userID)).
+	resp, err := o.requestContext(ctx, "users.quota").SetPathParam("userID", fmt.Sprintf("%d", userID)).
 		SetResult(&quota).Get("/user/{userID}/quota")
 	if err != nil {
 		return nil, err
@@ -169,8 +199,7 @@ func (o *Overseerr) GetUserQuota(userID int) (*UserQuota, error) {
 
 func (o *Overseerr) GetUserRequests(userID int, pageNumber, pageSize int) ([]*MediaRequest, *Page, error) {
 	var requests MediaRequestResponse
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("userID", fmt.Sprintf("%d", userID)).
+	resp, err := o.request("users.requests").SetPathParam("userID", fmt.Sprintf("%d", userID)).
 		SetQueryParams(map[string]string{
 			"take": fmt.Sprintf("%d", pageSize),
 			"skip": fmt.Sprintf("%d", pageSize*pageNumber),
@@ -187,8 +216,7 @@ func (o *Overseerr) GetUserRequests(userID int, pageNumber, pageSize int) ([]*Me
 
 func (o *Overseerr) GetUserGeneralSettings(userID int) (*GenerealUserSettings, error) {
 	var settings GenerealUserSettings
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("userID", fmt.Sprintf("%d", userID)).
+	resp, err := o.request("users.settings.get").SetPathParam("userID", fmt.Sprintf("%d", userID)).
 		SetResult(&settings).Get("/user/{userID}/settings/main")
 	if err != nil {
 		return nil, err
@@ -200,8 +228,7 @@ func (o *Overseerr) GetUserGeneralSettings(userID int) (*GenerealUserSettings, e
 }
 
 func (o *Overseerr) SetUserGeneralSettings(userID int, new GenerealUserSettings) error {
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").SetPathParam("userID", fmt.Sprintf("%d", userID)).
+	resp, err := o.request("users.settings.update").SetPathParam("userID", fmt.Sprintf("%d", userID)).
 		SetBody(new).Post("/user/{userID}/settings/main")
 	if err != nil {
 		return err
@@ -214,8 +241,7 @@ func (o *Overseerr) SetUserGeneralSettings(userID int, new GenerealUserSettings)
 
 func (o *Overseerr) ImportPlexUsers() ([]*User, error) {
 	var newUsers []*User
-	resp, err := o.restClient.R().
-		SetHeader("Accept", "application/json").
+	resp, err := o.request("users.import").
 		SetResult(&newUsers).Post("/user/import-from-plex")
 	if err != nil {
 		return nil, err
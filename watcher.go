@@ -0,0 +1,353 @@
+package goverseerr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+type WatchEventType string
+
+const (
+	RequestCreated          WatchEventType = "request_created"
+	RequestApproved         WatchEventType = "request_approved"
+	RequestDeclined         WatchEventType = "request_declined"
+	RequestDeleted          WatchEventType = "request_deleted"
+	MediaAvailable          WatchEventType = "media_available"
+	MediaPartiallyAvailable WatchEventType = "media_partially_available"
+)
+
+// WatchEvent describes a single change observed by a Watcher. Request is nil
+// for media events that were not observed through a request, and only holds
+// the ID for RequestDeleted events.
+type WatchEvent struct {
+	Type    WatchEventType
+	Request *MediaRequest
+	Media   *MediaInfo
+	Time    time.Time
+}
+
+// WatcherState is everything a Watcher needs to resume without replaying
+// events it has already emitted.
+type WatcherState struct {
+	RequestsModified time.Time             `json:"requestsModified"`
+	MediaModified    time.Time             `json:"mediaModified"`
+	Requests         map[int]RequestStatus `json:"requests"`
+	Media            map[int]MediaStatus   `json:"media"`
+}
+
+// WatcherStore persists WatcherState between runs. Load should return a nil
+// state and no error when nothing has been saved yet.
+type WatcherStore interface {
+	Load() (*WatcherState, error)
+	Save(state *WatcherState) error
+}
+
+type WatcherOptions struct {
+	// Interval between polls, defaults to 30 seconds.
+	Interval time.Duration
+	// PageSize used when paging through requests and media, defaults to 50.
+	PageSize int
+	// FullSyncEvery forces a full fetch every n polls so deleted requests
+	// can be detected, defaults to 10.
+	FullSyncEvery int
+	// WatchMedia also polls media directly so availability changes for media
+	// without a request are observed.
+	WatchMedia bool
+	// EmitInitial emits events for everything found on the first poll when
+	// no previous state exists.
+	EmitInitial bool
+	// Store persists state between runs, state is only held in memory if nil.
+	Store WatcherStore
+	// OnError is called with any error encountered while polling.
+	OnError func(err error)
+	// Buffer is the capacity of the events channel.
+	Buffer int
+}
+
+type Watcher struct {
+	client *Overseerr
+	opts   WatcherOptions
+	state  *WatcherState
+	polls  int
+	events chan WatchEvent
+}
+
+type fileWatcherStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewWatcher creates a Watcher for the Overseerr instance. Events are
+// emitted on the Events channel once Run is called.
+func (o *Overseerr) NewWatcher(opts WatcherOptions) *Watcher {
+	if opts.Interval <= 0 {
+		opts.Interval = 30 * time.Second
+	}
+	if opts.PageSize < 1 {
+		opts.PageSize = 50
+	}
+	if opts.FullSyncEvery < 1 {
+		opts.FullSyncEvery = 10
+	}
+	return &Watcher{
+		client: o,
+		opts:   opts,
+		events: make(chan WatchEvent, opts.Buffer),
+	}
+}
+
+// NewFileWatcherStore persists watcher state as JSON at the given path.
+func NewFileWatcherStore(path string) WatcherStore {
+	return &fileWatcherStore{path: path}
+}
+
+// Events returns the channel events are emitted on. It is closed when Run
+// returns.
+func (w *Watcher) Events() <-chan WatchEvent {
+	return w.events
+}
+
+// Run polls until the context is cancelled.
+func (w *Watcher) Run(ctx context.Context) error {
+	defer close(w.events)
+	if err := w.load(); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+	for {
+		if err := w.poll(ctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if w.opts.OnError != nil {
+				w.opts.OnError(err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// load restores the state from the store, if there is one. Stores may
+// leave the maps nil, they are always made before polling.
+func (w *Watcher) load() error {
+	if w.opts.Store == nil {
+		return nil
+	}
+	state, err := w.opts.Store.Load()
+	if err != nil || state == nil {
+		return err
+	}
+	if state.Requests == nil {
+		state.Requests = make(map[int]RequestStatus)
+	}
+	if state.Media == nil {
+		state.Media = make(map[int]MediaStatus)
+	}
+	w.state = state
+	return nil
+}
+
+// poll fetches changes and emits events for them. The first poll seeds the
+// state, which is only kept once seeding succeeds so a failed seed is retried
+// rather than treated as the starting state.
+func (w *Watcher) poll(ctx context.Context) error {
+	state := w.state
+	seeding := state == nil
+	if seeding {
+		state = &WatcherState{
+			Requests: make(map[int]RequestStatus),
+			Media:    make(map[int]MediaStatus),
+		}
+	}
+	emit := !seeding || w.opts.EmitInitial
+	full := seeding || w.polls%w.opts.FullSyncEvery == 0
+	w.polls++
+
+	since := state.RequestsModified
+	if full {
+		since = time.Time{}
+	}
+	requests, err := w.fetchRequests(since)
+	if err != nil {
+		return err
+	}
+	seen := make(map[int]bool, len(requests))
+	for idx := len(requests) - 1; idx >= 0; idx-- {
+		req := requests[idx]
+		seen[req.ID] = true
+		if req.Modified.After(state.RequestsModified) {
+			state.RequestsModified = req.Modified
+		}
+		previous, known := state.Requests[req.ID]
+		state.Requests[req.ID] = req.Status
+		if emit {
+			if eventType, ok := requestEventType(previous, req.Status, known); ok {
+				if err := w.emit(ctx, WatchEvent{Type: eventType, Request: req, Media: &req.Media, Time: req.Modified}); err != nil {
+					return err
+				}
+			}
+		}
+		if err := w.observeMedia(ctx, state, &req.Media, req, emit); err != nil {
+			return err
+		}
+	}
+	if full && !seeding {
+		for id := range state.Requests {
+			if seen[id] {
+				continue
+			}
+			delete(state.Requests, id)
+			if err := w.emit(ctx, WatchEvent{Type: RequestDeleted, Request: &MediaRequest{ID: id}, Time: time.Now()}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if w.opts.WatchMedia {
+		media, err := w.fetchMedia(state.MediaModified)
+		if err != nil {
+			return err
+		}
+		for idx := len(media) - 1; idx >= 0; idx-- {
+			if media[idx].Modified.After(state.MediaModified) {
+				state.MediaModified = media[idx].Modified
+			}
+			if err := w.observeMedia(ctx, state, media[idx], nil, emit); err != nil {
+				return err
+			}
+		}
+	}
+
+	w.state = state
+	if w.opts.Store != nil {
+		return w.opts.Store.Save(state)
+	}
+	return nil
+}
+
+func (w *Watcher) observeMedia(ctx context.Context, state *WatcherState, media *MediaInfo, req *MediaRequest, emit bool) error {
+	if media.ID == 0 {
+		return nil
+	}
+	previous := state.Media[media.ID]
+	state.Media[media.ID] = media.Status
+	if !emit || previous == media.Status {
+		return nil
+	}
+	event := WatchEvent{Request: req, Media: media, Time: media.Modified}
+	switch media.Status {
+	case MediaStatusAvailable:
+		event.Type = MediaAvailable
+	case MediaStatusPartial:
+		event.Type = MediaPartiallyAvailable
+	default:
+		return nil
+	}
+	return w.emit(ctx, event)
+}
+
+func (w *Watcher) emit(ctx context.Context, event WatchEvent) error {
+	select {
+	case w.events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// fetchRequests returns requests modified at or after since, newest first.
+func (w *Watcher) fetchRequests(since time.Time) ([]*MediaRequest, error) {
+	var all []*MediaRequest
+	for page := 0; ; page++ {
+		requests, pageInfo, err := w.client.GetRequests(page, w.opts.PageSize, RequestFileterAll, RequestSortModified)
+		if err != nil {
+			return nil, err
+		}
+		for _, req := range requests {
+			if req.Modified.Before(since) {
+				return all, nil
+			}
+			all = append(all, req)
+		}
+		if len(requests) == 0 || page+1 >= pageInfo.Pages {
+			return all, nil
+		}
+	}
+}
+
+// fetchMedia returns media modified at or after since, newest first.
+func (w *Watcher) fetchMedia(since time.Time) ([]*MediaInfo, error) {
+	var all []*MediaInfo
+	for page := 0; ; page++ {
+		media, pageInfo, err := w.client.GetMedia(page, w.opts.PageSize, MediaFilterAll, MediaSortModified)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range media {
+			if m.Modified.Before(since) {
+				return all, nil
+			}
+			all = append(all, m)
+		}
+		if len(media) == 0 || page+1 >= pageInfo.Pages {
+			return all, nil
+		}
+	}
+}
+
+func requestEventType(previous, current RequestStatus, known bool) (WatchEventType, bool) {
+	if !known {
+		return RequestCreated, true
+	}
+	if previous == current {
+		return "", false
+	}
+	switch current {
+	case RequestStatusApproved:
+		return RequestApproved, true
+	case RequestStatusDeclined:
+		return RequestDeclined, true
+	default:
+		return "", false
+	}
+}
+
+func (s *fileWatcherStore) Load() (*WatcherState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var state WatcherState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (s *fileWatcherStore) Save(state *WatcherState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,199 @@
+package goverseerr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRequests serves a mutable list of requests sorted by modification
+// time, newest first, as Overseerr does.
+type fakeRequests struct {
+	mu       sync.Mutex
+	requests map[int]*MediaRequest
+	fail     bool
+}
+
+func (f *fakeRequests) set(id int, status RequestStatus, media MediaStatus, modified time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests[id] = &MediaRequest{ID: id, Status: status, Modified: modified, Media: MediaInfo{ID: id, Status: media}}
+}
+
+func (f *fakeRequests) remove(id int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.requests, id)
+}
+
+func (f *fakeRequests) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fail || r.URL.Path != "/api/v1/request" {
+		http.Error(w, "{}", http.StatusInternalServerError)
+		return
+	}
+	all := make([]*MediaRequest, 0, len(f.requests))
+	for _, req := range f.requests {
+		all = append(all, req)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Modified.After(all[j].Modified) })
+	take, _ := strconv.Atoi(r.URL.Query().Get("take"))
+	skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+	page := all[min(skip, len(all)):min(skip+take, len(all))]
+	json.NewEncoder(w).Encode(MediaRequestResponse{
+		PageInfo: Page{Pages: (len(all) + take - 1) / take, Results: len(all)},
+		Results:  page,
+	})
+}
+
+func newTestWatcher(t *testing.T, opts WatcherOptions) (*Watcher, *fakeRequests) {
+	t.Helper()
+	fake := &fakeRequests{requests: make(map[int]*MediaRequest)}
+	opts.PageSize = 2
+	opts.Buffer = 100
+	w := newTestClient(t, fake.serve).NewWatcher(opts)
+	if err := w.load(); err != nil {
+		t.Fatal(err)
+	}
+	return w, fake
+}
+
+func pollEvents(t *testing.T, w *Watcher) []WatchEventType {
+	t.Helper()
+	if err := w.poll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	var events []WatchEventType
+	for len(w.events) > 0 {
+		events = append(events, (<-w.events).Type)
+	}
+	return events
+}
+
+func equalEvents(a, b []WatchEventType) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if a[idx] != b[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestWatcherSeeding(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		emitInitial bool
+		failFirst   bool
+		want        []WatchEventType
+	}{
+		{name: "quiet seed"},
+		{name: "emit initial", emitInitial: true, want: []WatchEventType{RequestCreated, RequestCreated, RequestCreated, MediaAvailable}},
+		{name: "failed seed retried quietly", failFirst: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, fake := newTestWatcher(t, WatcherOptions{EmitInitial: tt.emitInitial})
+			fake.set(1, RequestStatusPending, MediaStatusPending, start)
+			fake.set(2, RequestStatusApproved, MediaStatusProcessing, start.Add(time.Minute))
+			fake.set(3, RequestStatusApproved, MediaStatusAvailable, start.Add(2*time.Minute))
+			if tt.failFirst {
+				fake.fail = true
+				if err := w.poll(context.Background()); err == nil {
+					t.Fatal("poll succeeded against a failing server")
+				}
+				fake.fail = false
+			}
+			if got := pollEvents(t, w); !equalEvents(got, tt.want) {
+				t.Fatalf("events = %v, want %v", got, tt.want)
+			}
+			if got := pollEvents(t, w); len(got) != 0 {
+				t.Fatalf("second poll events = %v, want none", got)
+			}
+		})
+	}
+}
+
+func TestWatcherIncrementalChanges(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	w, fake := newTestWatcher(t, WatcherOptions{})
+	fake.set(1, RequestStatusPending, MediaStatusPending, start)
+	fake.set(2, RequestStatusPending, MediaStatusPending, start.Add(time.Minute))
+	pollEvents(t, w)
+
+	fake.set(1, RequestStatusApproved, MediaStatusProcessing, start.Add(2*time.Minute))
+	fake.set(3, RequestStatusPending, MediaStatusPending, start.Add(3*time.Minute))
+	if got, want := pollEvents(t, w), []WatchEventType{RequestApproved, RequestCreated}; !equalEvents(got, want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+
+	fake.set(2, RequestStatusDeclined, MediaStatusPending, start.Add(4*time.Minute))
+	fake.set(1, RequestStatusApproved, MediaStatusPartial, start.Add(5*time.Minute))
+	fake.set(3, RequestStatusApproved, MediaStatusAvailable, start.Add(6*time.Minute))
+	want := []WatchEventType{RequestDeclined, MediaPartiallyAvailable, RequestApproved, MediaAvailable}
+	if got := pollEvents(t, w); !equalEvents(got, want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+}
+
+func TestWatcherDeletionOnFullSync(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	w, fake := newTestWatcher(t, WatcherOptions{FullSyncEvery: 2})
+	fake.set(1, RequestStatusPending, MediaStatusPending, start)
+	fake.set(2, RequestStatusPending, MediaStatusPending, start.Add(time.Minute))
+	pollEvents(t, w)
+
+	fake.remove(1)
+	if got := pollEvents(t, w); len(got) != 0 {
+		t.Fatalf("incremental poll events = %v, want none", got)
+	}
+	if got, want := pollEvents(t, w), []WatchEventType{RequestDeleted}; !equalEvents(got, want) {
+		t.Fatalf("full sync events = %v, want %v", got, want)
+	}
+	if _, ok := w.state.Requests[1]; ok {
+		t.Fatal("deleted request still in state")
+	}
+}
+
+func TestWatcherRestartWithoutReplay(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewFileWatcherStore(filepath.Join(t.TempDir(), "state.json"))
+	w, fake := newTestWatcher(t, WatcherOptions{Store: store, EmitInitial: true})
+	fake.set(1, RequestStatusPending, MediaStatusPending, start)
+	if got := pollEvents(t, w); len(got) != 1 {
+		t.Fatalf("events = %v, want one request created", got)
+	}
+
+	restarted := w.client.NewWatcher(WatcherOptions{Store: store, EmitInitial: true, Buffer: 100})
+	if err := restarted.load(); err != nil {
+		t.Fatal(err)
+	}
+	fake.set(2, RequestStatusPending, MediaStatusPending, start.Add(time.Minute))
+	if got, want := pollEvents(t, restarted), []WatchEventType{RequestCreated}; !equalEvents(got, want) {
+		t.Fatalf("events after restart = %v, want %v", got, want)
+	}
+}
+
+// emptyStore returns state without the maps a store may leave unset.
+type emptyStore struct{}
+
+func (emptyStore) Load() (*WatcherState, error) { return &WatcherState{}, nil }
+func (emptyStore) Save(*WatcherState) error     { return nil }
+
+func TestWatcherStoreWithoutMaps(t *testing.T) {
+	w, fake := newTestWatcher(t, WatcherOptions{Store: emptyStore{}})
+	fake.set(1, RequestStatusPending, MediaStatusPending, time.Now())
+	if got, want := pollEvents(t, w), []WatchEventType{RequestCreated}; !equalEvents(got, want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+}
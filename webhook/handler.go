@@ -0,0 +1,95 @@
+// Package webhook receives and decodes notifications sent by Overseerr's
+// webhook agent.
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+const maxPayloadBytes int64 = 1 << 20
+
+// Callback handles a decoded webhook payload. The context is the incoming
+// HTTP request's context.
+type Callback func(ctx context.Context, payload *Payload)
+
+// Handler is an http.Handler that receives Overseerr webhook notifications
+// and dispatches them to registered callbacks and an optional channel.
+type Handler struct {
+	authorization string
+	mu            sync.RWMutex
+	callbacks     map[Event][]Callback
+	any           []Callback
+	events        chan<- *Payload
+}
+
+// NewHandler creates a Handler. If authorization is not empty requests must
+// carry a matching Authorization header, as configured in the webhook
+// agent's settings.
+func NewHandler(authorization string) *Handler {
+	return &Handler{
+		authorization: authorization,
+		callbacks:     make(map[Event][]Callback),
+	}
+}
+
+// On registers a callback for a single event type.
+func (h *Handler) On(event Event, callback Callback) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.callbacks[event] = append(h.callbacks[event], callback)
+}
+
+// OnAny registers a callback for every event.
+func (h *Handler) OnAny(callback Callback) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.any = append(h.any, callback)
+}
+
+// SetChannel sends every decoded payload to the given channel. Sends block
+// until the payload is received or the webhook request is cancelled.
+func (h *Handler) SetChannel(events chan<- *Payload) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = events
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.authorization != "" &&
+		subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(h.authorization)) != 1 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	var payload Payload
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPayloadBytes)).Decode(&payload); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	h.mu.RLock()
+	callbacks := append(append([]Callback{}, h.callbacks[payload.NotificationType]...), h.any...)
+	events := h.events
+	h.mu.RUnlock()
+
+	for _, callback := range callbacks {
+		callback(r.Context(), &payload)
+	}
+	if events != nil {
+		select {
+		case events <- &payload:
+		case <-r.Context().Done():
+			http.Error(w, "receiver busy", http.StatusServiceUnavailable)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
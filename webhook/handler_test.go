@@ -0,0 +1,115 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readTestPayload(t *testing.T, file string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestHandlerRejects(t *testing.T) {
+	valid := readTestPayload(t, "media_available.json")
+	tests := []struct {
+		name          string
+		method        string
+		authorization string
+		body          string
+		want          int
+	}{
+		{name: "get", method: http.MethodGet, authorization: "secret", want: http.StatusMethodNotAllowed},
+		{name: "missing authorization", method: http.MethodPost, body: valid, want: http.StatusUnauthorized},
+		{name: "wrong authorization", method: http.MethodPost, authorization: "wrong", body: valid, want: http.StatusUnauthorized},
+		{name: "malformed body", method: http.MethodPost, authorization: "secret", body: `{"notification_type": `, want: http.StatusBadRequest},
+		{name: "wrong body type", method: http.MethodPost, authorization: "secret", body: `{"media": []}`, want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler("secret")
+			h.OnAny(func(ctx context.Context, payload *Payload) {
+				t.Errorf("callback called for a rejected request")
+			})
+			req := httptest.NewRequest(tt.method, "/webhook", strings.NewReader(tt.body))
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if tt.want == http.StatusMethodNotAllowed && rec.Header().Get("Allow") != http.MethodPost {
+				t.Errorf("Allow = %q, want POST", rec.Header().Get("Allow"))
+			}
+		})
+	}
+}
+
+func TestHandlerDispatch(t *testing.T) {
+	tests := []struct {
+		file  string
+		event Event
+	}{
+		{file: "media_available.json", event: EventMediaAvailable},
+		{file: "issue_comment.json", event: EventIssueComment},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			h := NewHandler("")
+			var called []string
+			h.On(EventMediaAvailable, func(ctx context.Context, payload *Payload) {
+				called = append(called, string(EventMediaAvailable))
+			})
+			h.On(EventIssueComment, func(ctx context.Context, payload *Payload) {
+				called = append(called, string(EventIssueComment))
+			})
+			h.OnAny(func(ctx context.Context, payload *Payload) {
+				called = append(called, "any")
+			})
+			events := make(chan *Payload, 1)
+			h.SetChannel(events)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(readTestPayload(t, tt.file)))
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != http.StatusNoContent {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusNoContent)
+			}
+			if want := []string{string(tt.event), "any"}; strings.Join(called, ",") != strings.Join(want, ",") {
+				t.Errorf("callbacks = %v, want %v", called, want)
+			}
+			select {
+			case payload := <-events:
+				if payload.NotificationType != tt.event {
+					t.Errorf("channel payload = %s, want %s", payload.NotificationType, tt.event)
+				}
+			default:
+				t.Error("payload not sent to the channel")
+			}
+		})
+	}
+}
+
+func TestHandlerChannelBusy(t *testing.T) {
+	h := NewHandler("")
+	h.SetChannel(make(chan *Payload))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(readTestPayload(t, "media_available.json"))).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+}
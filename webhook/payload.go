@@ -0,0 +1,234 @@
+package webhook
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/willfantom/goverseerr"
+)
+
+// Event is the notification_type Overseerr sends with each webhook.
+type Event string
+
+const (
+	EventTest               Event = "TEST_NOTIFICATION"
+	EventMediaPending       Event = "MEDIA_PENDING"
+	EventMediaApproved      Event = "MEDIA_APPROVED"
+	EventMediaAutoApproved  Event = "MEDIA_AUTO_APPROVED"
+	EventMediaAutoRequested Event = "MEDIA_AUTO_REQUESTED"
+	EventMediaAvailable     Event = "MEDIA_AVAILABLE"
+	EventMediaDeclined      Event = "MEDIA_DECLINED"
+	EventMediaFailed        Event = "MEDIA_FAILED"
+	EventIssueCreated       Event = "ISSUE_CREATED"
+	EventIssueComment       Event = "ISSUE_COMMENT"
+	EventIssueResolved      Event = "ISSUE_RESOLVED"
+	EventIssueReopened      Event = "ISSUE_REOPENED"
+)
+
+type IssueType string
+type IssueStatus string
+
+const (
+	IssueTypeVideo     IssueType = "VIDEO"
+	IssueTypeAudio     IssueType = "AUDIO"
+	IssueTypeSubtitles IssueType = "SUBTITLES"
+	IssueTypeOther     IssueType = "OTHER"
+)
+
+const (
+	IssueStatusOpen     IssueStatus = "OPEN"
+	IssueStatusResolved IssueStatus = "RESOLVED"
+)
+
+// Payload is Overseerr's default webhook payload. Media, Request, Issue and
+// Comment are nil when the notification does not relate to one.
+type Payload struct {
+	NotificationType Event    `json:"notification_type"`
+	Event            string   `json:"event"`
+	Subject          string   `json:"subject"`
+	Message          string   `json:"message"`
+	Image            string   `json:"image"`
+	Media            *Media   `json:"media"`
+	Request          *Request `json:"request"`
+	Issue            *Issue   `json:"issue"`
+	Comment          *Comment `json:"comment"`
+	Extra            []Extra  `json:"extra"`
+}
+
+type Media struct {
+	MediaType goverseerr.MediaType
+	TMDB      int
+	TVDB      int
+	Status    goverseerr.MediaStatus
+	Status4K  goverseerr.MediaStatus
+}
+
+type Request struct {
+	ID                  int
+	RequestedByEmail    string
+	RequestedByUsername string
+	RequestedByAvatar   string
+}
+
+type Issue struct {
+	ID                 int
+	Type               IssueType
+	Status             IssueStatus
+	ReportedByEmail    string
+	ReportedByUsername string
+	ReportedByAvatar   string
+}
+
+type Comment struct {
+	Message             string
+	CommentedByEmail    string
+	CommentedByUsername string
+	CommentedByAvatar   string
+}
+
+type Extra struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type rawMedia struct {
+	MediaType string `json:"media_type"`
+	TMDB      string `json:"tmdbId"`
+	TVDB      string `json:"tvdbId"`
+	Status    string `json:"status"`
+	Status4K  string `json:"status4k"`
+}
+
+type rawRequest struct {
+	ID                  string `json:"request_id"`
+	RequestedByEmail    string `json:"requestedBy_email"`
+	RequestedByUsername string `json:"requestedBy_username"`
+	RequestedByAvatar   string `json:"requestedBy_avatar"`
+}
+
+type rawIssue struct {
+	ID                 string `json:"issue_id"`
+	Type               string `json:"issue_type"`
+	Status             string `json:"issue_status"`
+	ReportedByEmail    string `json:"reportedBy_email"`
+	ReportedByUsername string `json:"reportedBy_username"`
+	ReportedByAvatar   string `json:"reportedBy_avatar"`
+}
+
+type rawComment struct {
+	Message             string `json:"comment_message"`
+	CommentedByEmail    string `json:"commentedBy_email"`
+	CommentedByUsername string `json:"commentedBy_username"`
+	CommentedByAvatar   string `json:"commentedBy_avatar"`
+}
+
+// Bit returns the goverseerr.NotificationType bit for the event.
+func (e Event) Bit() goverseerr.NotificationType {
+	switch e {
+	case EventTest:
+		return goverseerr.NotificationTypeTest
+	case EventMediaPending:
+		return goverseerr.NotificationTypeMediaPending
+	case EventMediaApproved:
+		return goverseerr.NotificationTypeMediaApproved
+	case EventMediaAutoApproved:
+		return goverseerr.NotificationTypeMediaAutoApproved
+	case EventMediaAutoRequested:
+		return goverseerr.NotificationTypeMediaAutoRequested
+	case EventMediaAvailable:
+		return goverseerr.NotificationTypeMediaAvailable
+	case EventMediaDeclined:
+		return goverseerr.NotificationTypeMediaDeclined
+	case EventMediaFailed:
+		return goverseerr.NotificationTypeMediaFailed
+	case EventIssueCreated:
+		return goverseerr.NotificationTypeIssueCreated
+	case EventIssueComment:
+		return goverseerr.NotificationTypeIssueComment
+	case EventIssueResolved:
+		return goverseerr.NotificationTypeIssueResolved
+	case EventIssueReopened:
+		return goverseerr.NotificationTypeIssueReopened
+	default:
+		return goverseerr.NotificationTypeNone
+	}
+}
+
+// ParseMediaStatus converts the status names Overseerr uses in webhooks
+// (e.g. PARTIALLY_AVAILABLE) to a MediaStatus.
+func ParseMediaStatus(status string) goverseerr.MediaStatus {
+	switch strings.ToUpper(status) {
+	case "PENDING":
+		return goverseerr.MediaStatusPending
+	case "PROCESSING":
+		return goverseerr.MediaStatusProcessing
+	case "PARTIALLY_AVAILABLE":
+		return goverseerr.MediaStatusPartial
+	case "AVAILABLE":
+		return goverseerr.MediaStatusAvailable
+	default:
+		return goverseerr.MediaStatusUnknown
+	}
+}
+
+func (m *Media) UnmarshalJSON(data []byte) error {
+	var raw rawMedia
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*m = Media{
+		MediaType: goverseerr.MediaType(raw.MediaType),
+		TMDB:      atoi(raw.TMDB),
+		TVDB:      atoi(raw.TVDB),
+		Status:    ParseMediaStatus(raw.Status),
+		Status4K:  ParseMediaStatus(raw.Status4K),
+	}
+	return nil
+}
+
+func (r *Request) UnmarshalJSON(data []byte) error {
+	var raw rawRequest
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*r = Request{
+		ID:                  atoi(raw.ID),
+		RequestedByEmail:    raw.RequestedByEmail,
+		RequestedByUsername: raw.RequestedByUsername,
+		RequestedByAvatar:   raw.RequestedByAvatar,
+	}
+	return nil
+}
+
+func (i *Issue) UnmarshalJSON(data []byte) error {
+	var raw rawIssue
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*i = Issue{
+		ID:                 atoi(raw.ID),
+		Type:               IssueType(strings.ToUpper(raw.Type)),
+		Status:             IssueStatus(strings.ToUpper(raw.Status)),
+		ReportedByEmail:    raw.ReportedByEmail,
+		ReportedByUsername: raw.ReportedByUsername,
+		ReportedByAvatar:   raw.ReportedByAvatar,
+	}
+	return nil
+}
+
+func (c *Comment) UnmarshalJSON(data []byte) error {
+	var raw rawComment
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*c = Comment(raw)
+	return nil
+}
+
+// atoi parses the numeric IDs Overseerr templates as strings, empty or
+// invalid values are treated as 0.
+func atoi(s string) int {
+	v, _ := strconv.Atoi(strings.TrimSpace(s))
+	return v
+}
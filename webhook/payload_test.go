@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/willfantom/goverseerr"
+)
+
+func TestPayloadDecode(t *testing.T) {
+	tests := []struct {
+		file string
+		want Payload
+	}{
+		{
+			file: "media_available.json",
+			want: Payload{
+				NotificationType: EventMediaAvailable,
+				Event:            "Movie Request Now Available",
+				Subject:          "Kiki's Delivery Service (1989)",
+				Message:          "A young witch, on her mandatory year of independent life, finds fitting into a new community difficult.",
+				Image:            "https://image.tmdb.org/t/p/w600_and_h900_bestv2/7nO5DUMnGUuXrA4r2h6ESOKQRrx.jpg",
+				Media: &Media{
+					MediaType: goverseerr.MediaTypeMovie,
+					TMDB:      16859,
+					Status:    goverseerr.MediaStatusAvailable,
+					Status4K:  goverseerr.MediaStatusUnknown,
+				},
+				Request: &Request{
+					ID:                  42,
+					RequestedByEmail:    "user@example.com",
+					RequestedByUsername: "user",
+					RequestedByAvatar:   "https://plex.tv/users/0123456789abcdef/avatar?c=1700000000",
+				},
+				Extra: []Extra{},
+			},
+		},
+		{
+			file: "issue_comment.json",
+			want: Payload{
+				NotificationType: EventIssueComment,
+				Event:            "New Comment on Subtitle Issue",
+				Subject:          "Breaking Bad (2008)",
+				Message:          "Subtitles are out of sync in episode 5.",
+				Image:            "https://image.tmdb.org/t/p/w600_and_h900_bestv2/ggFHVNu6YYI5L9pCfOacjizRGt.jpg",
+				Media: &Media{
+					MediaType: goverseerr.MediaTypeTV,
+					TMDB:      1396,
+					TVDB:      81189,
+					Status:    goverseerr.MediaStatusPartial,
+					Status4K:  goverseerr.MediaStatusUnknown,
+				},
+				Issue: &Issue{
+					ID:                 7,
+					Type:               IssueTypeSubtitles,
+					Status:             IssueStatusOpen,
+					ReportedByEmail:    "user@example.com",
+					ReportedByUsername: "user",
+					ReportedByAvatar:   "/os_logo_square.png",
+				},
+				Comment: &Comment{
+					Message:             "Still out of sync after the rescan.",
+					CommentedByEmail:    "admin@example.com",
+					CommentedByUsername: "admin",
+					CommentedByAvatar:   "/os_logo_square.png",
+				},
+				Extra: []Extra{{Name: "Affected Season", Value: "2"}, {Name: "Affected Episode", Value: "5"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got Payload
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decoded %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
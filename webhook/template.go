@@ -0,0 +1,84 @@
+package webhook
+
+import "github.com/willfantom/goverseerr"
+
+// payloadTemplate mirrors the fields decoded into Payload. Overseerr replaces
+// the {{media}}, {{request}}, {{issue}} and {{comment}} keys with their
+// names when the notification relates to one, and drops them otherwise.
+const payloadTemplate string = `{
+  "notification_type": "{{notification_type}}",
+  "event": "{{event}}",
+  "subject": "{{subject}}",
+  "message": "{{message}}",
+  "image": "{{image}}",
+  "{{media}}": {
+    "media_type": "{{media_type}}",
+    "tmdbId": "{{media_tmdbid}}",
+    "tvdbId": "{{media_tvdbid}}",
+    "status": "{{media_status}}",
+    "status4k": "{{media_status4k}}"
+  },
+  "{{request}}": {
+    "request_id": "{{request_id}}",
+    "requestedBy_email": "{{requestedBy_email}}",
+    "requestedBy_username": "{{requestedBy_username}}",
+    "requestedBy_avatar": "{{requestedBy_avatar}}"
+  },
+  "{{issue}}": {
+    "issue_id": "{{issue_id}}",
+    "issue_type": "{{issue_type}}",
+    "issue_status": "{{issue_status}}",
+    "reportedBy_email": "{{reportedBy_email}}",
+    "reportedBy_username": "{{reportedBy_username}}",
+    "reportedBy_avatar": "{{reportedBy_avatar}}"
+  },
+  "{{comment}}": {
+    "comment_message": "{{comment_message}}",
+    "commentedBy_email": "{{commentedBy_email}}",
+    "commentedBy_username": "{{commentedBy_username}}",
+    "commentedBy_avatar": "{{commentedBy_avatar}}"
+  },
+  "{{extra}}": []
+}`
+
+// AllEvents enables every notification type a Handler can decode.
+const AllEvents goverseerr.NotificationType = goverseerr.NotificationTypeMediaPending |
+	goverseerr.NotificationTypeMediaApproved |
+	goverseerr.NotificationTypeMediaAvailable |
+	goverseerr.NotificationTypeMediaFailed |
+	goverseerr.NotificationTypeMediaDeclined |
+	goverseerr.NotificationTypeMediaAutoApproved |
+	goverseerr.NotificationTypeIssueCreated |
+	goverseerr.NotificationTypeIssueComment |
+	goverseerr.NotificationTypeIssueResolved |
+	goverseerr.NotificationTypeIssueReopened |
+	goverseerr.NotificationTypeMediaAutoRequested
+
+// PayloadTemplate returns the webhook agent JSON payload template that
+// produces payloads a Handler can decode.
+func PayloadTemplate() string {
+	return payloadTemplate
+}
+
+// Install enables the webhook notification agent on the Overseerr instance,
+// sending the given notification types to url with the PayloadTemplate. The
+// authorization header should match the one given to NewHandler. All events
+// are enabled if types is NotificationTypeNone. WebhookOptions sends the
+// template JSON encoded, as the webhook agent requires.
+func Install(o *goverseerr.Overseerr, url, authorization string, types goverseerr.NotificationType) (*goverseerr.NotificationAgentSettings[goverseerr.WebhookOptions], error) {
+	settings, err := o.GetWebhookNotificationSettings()
+	if err != nil {
+		return nil, err
+	}
+	if types == goverseerr.NotificationTypeNone {
+		types = AllEvents
+	}
+	settings.Enabled = true
+	settings.Types = types
+	settings.Options = goverseerr.WebhookOptions{
+		WebhookURL:  url,
+		AuthHeader:  authorization,
+		JSONPayload: payloadTemplate,
+	}
+	return o.UpdateWebhookNotificationSettings(*settings)
+}
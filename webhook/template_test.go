@@ -0,0 +1,197 @@
+package webhook
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/willfantom/goverseerr"
+)
+
+// fakeWebhookSettings stores the webhook agent settings the way Overseerr
+// does, base64 encoding the posted payload on save and decoding and parsing
+// it on load.
+type fakeWebhookSettings struct {
+	settings map[string]any
+	stored   string
+}
+
+func (f *fakeWebhookSettings) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.URL.Path == "/api/v1/auth/me":
+		json.NewEncoder(w).Encode(map[string]any{"id": 1})
+	case r.URL.Path == "/api/v1/settings/notifications/webhook" && r.Method == http.MethodPost:
+		var settings map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		options, _ := settings["options"].(map[string]any)
+		payload, ok := options["jsonPayload"].(string)
+		if !ok {
+			http.Error(w, "jsonPayload must be a string", http.StatusBadRequest)
+			return
+		}
+		f.stored = base64.StdEncoding.EncodeToString([]byte(payload))
+		f.settings = settings
+		f.writeSettings(w)
+	case r.URL.Path == "/api/v1/settings/notifications/webhook":
+		f.writeSettings(w)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeWebhookSettings) writeSettings(w http.ResponseWriter) {
+	if f.settings == nil {
+		json.NewEncoder(w).Encode(map[string]any{"enabled": false, "types": 0, "options": map[string]any{}})
+		return
+	}
+	// overseerr parses the stored value, returning the template itself
+	decoded, _ := base64.StdEncoding.DecodeString(f.stored)
+	var template string
+	json.Unmarshal(decoded, &template)
+	f.settings["options"].(map[string]any)["jsonPayload"] = template
+	json.NewEncoder(w).Encode(f.settings)
+}
+
+func TestInstallPayloadRoundTrip(t *testing.T) {
+	fake := &fakeWebhookSettings{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	o, err := goverseerr.NewKeyAuth(server.URL, nil, "en", "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Install(o, "http://example.com/hook", "secret", goverseerr.NotificationTypeNone); err != nil {
+		t.Fatal(err)
+	}
+
+	// the webhook agent parses the decoded payload twice
+	decoded, err := base64.StdEncoding.DecodeString(fake.stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var template string
+	if err := json.Unmarshal(decoded, &template); err != nil {
+		t.Fatalf("stored payload is not a JSON encoded string: %v", err)
+	}
+	var object map[string]any
+	if err := json.Unmarshal([]byte(template), &object); err != nil {
+		t.Fatalf("stored payload does not decode to a JSON object: %v", err)
+	}
+
+	settings, err := o.GetWebhookNotificationSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.Options.JSONPayload != PayloadTemplate() {
+		t.Fatalf("JSONPayload = %q, want the payload template", settings.Options.JSONPayload)
+	}
+	if !settings.Enabled || settings.Types != AllEvents {
+		t.Fatalf("settings = %+v, want enabled with all events", settings)
+	}
+}
+
+// fillTemplate renders a payload template the way Overseerr's webhook agent
+// does. Section keys such as {{media}} are renamed when the section is
+// present and set to null otherwise, and {{variable}} placeholders in string
+// values are replaced.
+func fillTemplate(v any, values map[string]string, sections map[string]bool) any {
+	switch v := v.(type) {
+	case map[string]any:
+		filled := make(map[string]any, len(v))
+		for key, value := range v {
+			if name, ok := strings.CutPrefix(key, "{{"); ok {
+				name = strings.TrimSuffix(name, "}}")
+				switch {
+				case name == "extra":
+					filled[name] = []any{map[string]any{"name": "Affected Season", "value": "2"}}
+				case sections[name]:
+					filled[name] = fillTemplate(value, values, sections)
+				default:
+					filled[name] = nil
+				}
+				continue
+			}
+			filled[key] = fillTemplate(value, values, sections)
+		}
+		return filled
+	case string:
+		for name, value := range values {
+			v = strings.ReplaceAll(v, "{{"+name+"}}", value)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func TestPayloadTemplateDecodes(t *testing.T) {
+	values := map[string]string{
+		"notification_type":    "ISSUE_CREATED",
+		"event":                "New Video Issue Reported",
+		"subject":              "Breaking Bad (2008)",
+		"message":              "The picture freezes.",
+		"image":                "https://image.tmdb.org/t/p/w600_and_h900_bestv2/poster.jpg",
+		"media_type":           "tv",
+		"media_tmdbid":         "1396",
+		"media_tvdbid":         "81189",
+		"media_status":         "AVAILABLE",
+		"media_status4k":       "UNKNOWN",
+		"issue_id":             "3",
+		"issue_type":           "VIDEO",
+		"issue_status":         "OPEN",
+		"reportedBy_email":     "user@example.com",
+		"reportedBy_username":  "user",
+		"reportedBy_avatar":    "/avatar.png",
+		"request_id":           "",
+		"requestedBy_email":    "",
+		"requestedBy_username": "",
+		"requestedBy_avatar":   "",
+	}
+	var template map[string]any
+	if err := json.Unmarshal([]byte(PayloadTemplate()), &template); err != nil {
+		t.Fatalf("template is not valid JSON: %v", err)
+	}
+	filled := fillTemplate(template, values, map[string]bool{"media": true, "issue": true})
+	data, err := json.Marshal(filled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Payload
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := Payload{
+		NotificationType: EventIssueCreated,
+		Event:            values["event"],
+		Subject:          values["subject"],
+		Message:          values["message"],
+		Image:            values["image"],
+		Media: &Media{
+			MediaType: goverseerr.MediaTypeTV,
+			TMDB:      1396,
+			TVDB:      81189,
+			Status:    goverseerr.MediaStatusAvailable,
+			Status4K:  goverseerr.MediaStatusUnknown,
+		},
+		Issue: &Issue{
+			ID:                 3,
+			Type:               IssueTypeVideo,
+			Status:             IssueStatusOpen,
+			ReportedByEmail:    "user@example.com",
+			ReportedByUsername: "user",
+			ReportedByAvatar:   "/avatar.png",
+		},
+		Extra: []Extra{{Name: "Affected Season", Value: "2"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("decoded %+v, want %+v", got, want)
+	}
+}